@@ -4,7 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/bits"
+	"math/cmplx"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/twolodzko/gol/environment"
 	"github.com/twolodzko/gol/parser"
@@ -18,6 +23,18 @@ var buildins = map[Symbol]Any{
 		// (def (<name> <arg>...) <expr>...)
 		defFn,
 	},
+	"defrecord": &simpleFunction{
+		// (defrecord <name> (<field>...))
+		defrecordFn,
+	},
+	"defmulti": &simpleFunction{
+		// (defmulti <name> <dispatch-fn>)
+		defmultiFn,
+	},
+	"defmethod": &simpleFunction{
+		// (defmethod <name> <dispatch-value | :default> (<arg>...) <expr>...)
+		defmethodFn,
+	},
 	"fn": &simpleFunction{
 		// (fn (<arg>...) <expr>...)
 		func(args []Any, env *environment.Env) (Any, error) {
@@ -35,10 +52,41 @@ var buildins = map[Symbol]Any{
 		// (cond (<condition> <expr>...)...)
 		condFun,
 	},
+	"match": &tcoFunction{
+		// (match <expr> (<pattern> [:when <guard>] <expr>...)...)
+		matchFn,
+	},
 	"let": &tcoFunction{
 		// (let (<name> <expr>...) <expr>...)
+		// (let <name> (<name> <expr>...) <expr>...)
 		letFn,
 	},
+	"loop": &tcoFunction{
+		// (loop (<name> <expr>...) <expr>...)
+		loopFn,
+	},
+	"recur": &simpleFunction{
+		// (recur <expr>...)
+		func(args []Any, env *environment.Env) (Any, error) {
+			return nil, errors.New("recur called outside of loop")
+		},
+	},
+	"dotimes": &simpleFunction{
+		// (dotimes (<name> <int>) <expr>...)
+		dotimesFn,
+	},
+	"while": &simpleFunction{
+		// (while <condition> <expr>...)
+		whileFn,
+	},
+	"doseq": &simpleFunction{
+		// (doseq (<name> <list>) <expr>...)
+		doseqFn,
+	},
+	"for-each": &simpleFunction{
+		// (for-each <expr> <list>)
+		forEachFn,
+	},
 	"begin": &tcoFunction{
 		// (begin <expr>...)
 		func(args []Any, env *environment.Env) (Any, *environment.Env, error) {
@@ -54,10 +102,26 @@ var buildins = map[Symbol]Any{
 		// (map <expr> <list>)
 		mapFn,
 	},
+	"filter": &simpleFunction{
+		// (filter <expr> <list>)
+		filterFn,
+	},
 	"set!": &simpleFunction{
 		// (set! <name> <expr>)
 		setFn,
 	},
+	"call/cc": &escapeFunction{
+		// (call/cc <fn>)
+		callCCFn,
+	},
+	"call-with-current-continuation": &escapeFunction{
+		// (call-with-current-continuation <fn>)
+		callCCFn,
+	},
+	"let/ec": &escapeFunction{
+		// (let/ec <name> <expr>...)
+		letEcFn,
+	},
 
 	// metaprogramming
 	"quote": &simpleFunction{
@@ -208,36 +272,125 @@ var buildins = map[Symbol]Any{
 	},
 	"reverse": &singleArgFunction{
 		// (reverse <list>)
+		// (reverse <string>)
 		func(obj Any) (Any, error) {
-			l, ok := obj.(List)
-			if !ok {
+			switch obj := obj.(type) {
+			case List:
+				return List(reverse(obj)), nil
+			case String:
+				return reverseString(obj)
+			default:
 				return nil, &ErrWrongType{obj}
 			}
-			return List(reverse(l)), nil
 		},
 	},
 	"empty?": &singleArgFunction{
 		// (empty? <list>)
+		// (empty? <map>)
 		func(obj Any) (Any, error) {
-			l, ok := obj.(List)
-			if !ok {
+			switch obj := obj.(type) {
+			case List:
+				return Bool(len(obj) == 0), nil
+			case Map:
+				return Bool(len(obj) == 0), nil
+			default:
 				return nil, &ErrWrongType{obj}
 			}
-			return Bool(len(l) == 0), nil
 		},
 	},
 	"count": &singleArgFunction{
 		// (count <list>)
+		// (count <map>)
 		func(obj Any) (Any, error) {
-			l, ok := obj.(List)
+			switch obj := obj.(type) {
+			case List:
+				return Int(len(obj)), nil
+			case Map:
+				return Int(len(obj)), nil
+			default:
+				return nil, &ErrWrongType{obj}
+			}
+		},
+	},
+
+	// maps
+	"hash-map": &multiArgFunction{
+		// (hash-map <key> <value>...)
+		hashMapFn,
+	},
+	"get": &multiArgFunction{
+		// (get <map> <key> [<default>])
+		// (get <record> <keyword> [<default>])
+		getFn,
+	},
+	"assoc": &multiArgFunction{
+		// (assoc <map> <key> <value>...)
+		// (assoc <record> <keyword> <value>...)
+		assocFn,
+	},
+	"dissoc": &multiArgFunction{
+		// (dissoc <map> <key>...)
+		dissocFn,
+	},
+	"contains?": &multiArgFunction{
+		// (contains? <map> <key>)
+		// (contains? <record> <keyword>)
+		containsFn,
+	},
+	"keys": &singleArgFunction{
+		// (keys <map>)
+		keysFn,
+	},
+	"vals": &singleArgFunction{
+		// (vals <map>)
+		valsFn,
+	},
+	"record->map": &singleArgFunction{
+		// (record->map <record>)
+		func(obj Any) (Any, error) {
+			r, ok := obj.(Record)
 			if !ok {
 				return nil, &ErrWrongType{obj}
 			}
-			return Int(len(l)), nil
+			return recordToMap(r), nil
+		},
+	},
+
+	// generators
+	"generator": &simpleFunction{
+		// (generator <fn>)
+		generatorFn,
+	},
+	"yield": &singleArgFunction{
+		// (yield <expr>)
+		func(obj Any) (Any, error) {
+			return nil, errors.New("yield called outside of a generator")
+		},
+	},
+	"next": &multiArgFunction{
+		// (next <iterator> [<default>])
+		nextFn,
+	},
+	"take": &multiArgFunction{
+		// (take <int> <list>)
+		takeFn,
+	},
+	"collect": &singleArgFunction{
+		// (collect <iterator>)
+		func(obj Any) (Any, error) {
+			it, ok := obj.(iterator)
+			if !ok {
+				return nil, &ErrWrongType{obj}
+			}
+			return collect(it)
 		},
 	},
 
 	// type checks
+	"type-of": &singleArgFunction{
+		// (type-of <expr>)
+		typeOf,
+	},
 	"nil?": &singleArgFunction{
 		// (nil? <expr>)
 		func(obj Any) (Any, error) {
@@ -247,7 +400,18 @@ var buildins = map[Symbol]Any{
 	"int?": &singleArgFunction{
 		// (int? <expr>)
 		func(obj Any) (Any, error) {
-			_, ok := obj.(Int)
+			switch obj.(type) {
+			case Int, BigInt:
+				return Bool(true), nil
+			default:
+				return Bool(false), nil
+			}
+		},
+	},
+	"rational?": &singleArgFunction{
+		// (rational? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Rational)
 			return Bool(ok), nil
 		},
 	},
@@ -258,6 +422,19 @@ var buildins = map[Symbol]Any{
 			return Bool(ok), nil
 		},
 	},
+	"number?": &singleArgFunction{
+		// (number? <expr>)
+		func(obj Any) (Any, error) {
+			return Bool(isNumber(obj)), nil
+		},
+	},
+	"complex?": &singleArgFunction{
+		// (complex? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Complex)
+			return Bool(ok), nil
+		},
+	},
 	"str?": &singleArgFunction{
 		// (str? <expr>)
 		func(obj Any) (Any, error) {
@@ -265,6 +442,40 @@ var buildins = map[Symbol]Any{
 			return Bool(ok), nil
 		},
 	},
+	"char?": &singleArgFunction{
+		// (char? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Char)
+			return Bool(ok), nil
+		},
+	},
+	"regex?": &singleArgFunction{
+		// (regex? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Regex)
+			return Bool(ok), nil
+		},
+	},
+	"keyword?": &singleArgFunction{
+		// (keyword? <expr>)
+		func(obj Any) (Any, error) {
+			return Bool(isKeyword(obj)), nil
+		},
+	},
+	"record?": &singleArgFunction{
+		// (record? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Record)
+			return Bool(ok), nil
+		},
+	},
+	"map?": &singleArgFunction{
+		// (map? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Map)
+			return Bool(ok), nil
+		},
+	},
 	"list?": &singleArgFunction{
 		// (list? <expr>)
 		func(obj Any) (Any, error) {
@@ -276,13 +487,20 @@ var buildins = map[Symbol]Any{
 		// (atom? <expr>)
 		func(obj Any) (Any, error) {
 			switch obj.(type) {
-			case Bool, Int, Float, String:
+			case Bool, Int, BigInt, Rational, Float, Complex, String, Char:
 				return Bool(true), nil
 			default:
 				return Bool(false), nil
 			}
 		},
 	},
+	"iterator?": &singleArgFunction{
+		// (iterator? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(iterator)
+			return Bool(ok), nil
+		},
+	},
 	"fn?": &singleArgFunction{
 		// (fn? <expr>)
 		func(obj Any) (Any, error) {
@@ -319,7 +537,7 @@ var buildins = map[Symbol]Any{
 			if len(objs) == 0 {
 				return nil, nil
 			}
-			str, err := toString(objs, " ")
+			str, err := toText(objs, " ")
 			if err != nil {
 				return nil, err
 			}
@@ -334,7 +552,7 @@ var buildins = map[Symbol]Any{
 				fmt.Println()
 				return nil, nil
 			}
-			str, err := toString(objs, " ")
+			str, err := toText(objs, " ")
 			if err != nil {
 				return nil, err
 			}
@@ -342,19 +560,37 @@ var buildins = map[Symbol]Any{
 			return nil, nil
 		},
 	},
+	"format": &multiArgFunction{
+		// (format <fmt> <expr>...)
+		formatFn,
+	},
+	"printf": &multiArgFunction{
+		// (printf <fmt> <expr>...)
+		func(objs []Any) (Any, error) {
+			str, err := formatFn(objs)
+			if err != nil {
+				return nil, err
+			}
+			text, err := getText(str)
+			if err != nil {
+				return nil, err
+			}
+			fmt.Print(text)
+			return nil, nil
+		},
+	},
 	"chars": &singleArgFunction{
 		// (chars <expr>)
 		func(obj Any) (Any, error) {
-			str, ok := obj.(String)
-			if !ok {
-				return nil, &ErrWrongType{obj}
+			str, err := getText(obj)
+			if err != nil {
+				return nil, err
 			}
-			var chars []Any
+			chars := List{}
 			for _, r := range str {
-				ch := String(string(r)).Quote()
-				chars = append(chars, ch)
+				chars = append(chars, Char(r))
 			}
-			return List(chars), nil
+			return chars, nil
 		},
 	},
 	"pretty-str": &singleArgFunction{
@@ -378,28 +614,371 @@ var buildins = map[Symbol]Any{
 		},
 	},
 
+	"substring": &multiArgFunction{
+		// (substring <string> <start> [<end>])
+		substringFn,
+	},
+	"split": &multiArgFunction{
+		// (split <string> [<separator>])
+		splitFn,
+	},
+	"join": &multiArgFunction{
+		// (join <list> [<separator>])
+		joinFn,
+	},
+	"trim": &multiArgFunction{
+		// (trim <string> [<cutset>])
+		trim.call,
+	},
+	"trim-left": &multiArgFunction{
+		// (trim-left <string> [<cutset>])
+		trimLeft.call,
+	},
+	"trim-right": &multiArgFunction{
+		// (trim-right <string> [<cutset>])
+		trimRight.call,
+	},
+	"upper": &singleArgFunction{
+		// (upper <string>)
+		func(obj Any) (Any, error) {
+			str, err := getText(obj)
+			return newText(strings.ToUpper(str)), err
+		},
+	},
+	"lower": &singleArgFunction{
+		// (lower <string>)
+		func(obj Any) (Any, error) {
+			str, err := getText(obj)
+			return newText(strings.ToLower(str)), err
+		},
+	},
+	"replace": &multiArgFunction{
+		// (replace <string> <old> <new>)
+		replaceFn,
+	},
+	"starts-with?": &multiArgFunction{
+		// (starts-with? <string> <prefix>)
+		stringPredicate(strings.HasPrefix),
+	},
+	"ends-with?": &multiArgFunction{
+		// (ends-with? <string> <suffix>)
+		stringPredicate(strings.HasSuffix),
+	},
+	"index-of": &multiArgFunction{
+		// (index-of <string> <substring>)
+		indexOfFn,
+	},
+	"string-length": &singleArgFunction{
+		// (string-length <string>)
+		func(obj Any) (Any, error) {
+			str, err := getText(obj)
+			return Int(utf8.RuneCountInString(str)), err
+		},
+	},
+	"repeat-str": &multiArgFunction{
+		// (repeat-str <string> <int>)
+		repeatStrFn,
+	},
+	"pad-left": &multiArgFunction{
+		// (pad-left <string> <width> [<padding>])
+		padFunction(true),
+	},
+	"pad-right": &multiArgFunction{
+		// (pad-right <string> <width> [<padding>])
+		padFunction(false),
+	},
+
+	// regular expressions
+	"regex": &singleArgFunction{
+		// (regex <string>)
+		func(obj Any) (Any, error) {
+			return getRegex(obj)
+		},
+	},
+	"re-find": &regexFunction{
+		// (re-find <regex> <string>)
+		reFind,
+	},
+	"re-matches": &regexFunction{
+		// (re-matches <regex> <string>)
+		reMatches,
+	},
+	"re-seq": &regexFunction{
+		// (re-seq <regex> <string>)
+		reSeq,
+	},
+	"re-groups": &regexFunction{
+		// (re-groups <regex> <string>)
+		reGroups,
+	},
+	"re-split": &regexFunction{
+		// (re-split <regex> <string>)
+		reSplit,
+	},
+	"re-replace": &simpleFunction{
+		// (re-replace <regex> <string> <string>)
+		// (re-replace <regex> <string> <fn>)
+		reReplaceFn,
+	},
+
+	// characters
+	"char->int": &singleArgFunction{
+		// (char->int <char>)
+		func(obj Any) (Any, error) {
+			c, ok := obj.(Char)
+			if !ok {
+				return nil, &ErrWrongType{obj}
+			}
+			return Int(c), nil
+		},
+	},
+	"int->char": &singleArgFunction{
+		// (int->char <int>)
+		func(obj Any) (Any, error) {
+			i, ok := obj.(Int)
+			if !ok || !utf8.ValidRune(rune(i)) || i != Int(rune(i)) {
+				return nil, fmt.Errorf("%v is not a valid code point", obj)
+			}
+			return Char(i), nil
+		},
+	},
+	"char-upper": &charFunction{
+		// (char-upper <char>)
+		func(c rune) Any { return Char(unicode.ToUpper(c)) },
+	},
+	"char-lower": &charFunction{
+		// (char-lower <char>)
+		func(c rune) Any { return Char(unicode.ToLower(c)) },
+	},
+	"char-alphabetic?": &charFunction{
+		// (char-alphabetic? <char>)
+		func(c rune) Any { return Bool(unicode.IsLetter(c)) },
+	},
+	"char-numeric?": &charFunction{
+		// (char-numeric? <char>)
+		func(c rune) Any { return Bool(unicode.IsDigit(c)) },
+	},
+	"char-whitespace?": &charFunction{
+		// (char-whitespace? <char>)
+		func(c rune) Any { return Bool(unicode.IsSpace(c)) },
+	},
+
 	// I/O
 	"read-file": &singleArgFunction{
 		// (read-file <filename>)
 		func(obj Any) (Any, error) {
-			name, ok := obj.(String)
-			if !ok {
+			if _, ok := obj.(String); !ok {
 				return nil, &ErrWrongType{obj}
 			}
-			lines, err := parser.ReadFile(string(name))
-			return String(lines), err
+			name, err := getText(obj)
+			if err != nil {
+				return nil, err
+			}
+			lines, err := parser.ReadFile(name)
+			return newText(lines), err
 		},
 	},
 	"write-to-file": &multiArgFunction{
 		// (write-to-file <filename> <expr>)
 		writeToFileFn,
 	},
+	"spit": &multiArgFunction{
+		// (spit <filename> <expr> [:append <bool>])
+		spitFn,
+	},
+	"slurp": &multiArgFunction{
+		// (slurp <filename> [:lines <bool>])
+		slurpFn,
+	},
+	"open": &multiArgFunction{
+		// (open <filename> [:read|:write|:append])
+		openFn,
+	},
+	"close": &singleArgFunction{
+		// (close <file or generator>)
+		closeFn,
+	},
+	"read-line": &multiArgFunction{
+		// (read-line [<file>])
+		readLineFn,
+	},
+	"read-lines": &multiArgFunction{
+		// (read-lines [<file or path>])
+		readLinesFn,
+	},
+	"stdin-seq": &multiArgFunction{
+		// (stdin-seq)
+		func(objs []Any) (Any, error) {
+			if len(objs) != 0 {
+				return nil, &ErrNumArgs{len(objs)}
+			}
+			return linesIterator(stdin, false), nil
+		},
+	},
+	"write": &multiArgFunction{
+		// (write <file> <expr>...)
+		writeFn,
+	},
+	"with-open": &simpleFunction{
+		// (with-open (<name> <file>...) <expr>...)
+		withOpenFn,
+	},
+	"file-exists?": &singleArgFunction{
+		// (file-exists? <path>)
+		fileExistsFn,
+	},
+	"delete-file": &singleArgFunction{
+		// (delete-file <path>)
+		deleteFileFn,
+	},
+	"list-dir": &singleArgFunction{
+		// (list-dir <path>)
+		listDirFn,
+	},
+	"make-dir": &singleArgFunction{
+		// (make-dir <path>)
+		makeDirFn,
+	},
+	"path-join": &multiArgFunction{
+		// (path-join <string>...)
+		pathJoinFn,
+	},
+	"file-size": &singleArgFunction{
+		// (file-size <path>)
+		fileSizeFn,
+	},
+	"glob": &singleArgFunction{
+		// (glob <pattern>)
+		globFn,
+	},
+
+	// csv
+	"csv-read": &multiArgFunction{
+		// (csv-read <filename> [:delimiter <char>] [:header <bool>] [:comment <char>]
+		//           [:lazy-quotes <bool>] [:trim-space <bool>])
+		csvReadFn,
+	},
+	"csv-parse": &multiArgFunction{
+		// (csv-parse <string> <options>...)
+		csvParseFn,
+	},
+	"csv-rows": &multiArgFunction{
+		// (csv-rows <filename> <options>...)
+		csvRowsFn,
+	},
+	"csv-write": &multiArgFunction{
+		// (csv-write <filename> <rows> [:delimiter <char>] [:header <bool or list>] [:crlf <bool>])
+		csvWriteFn,
+	},
+
+	// json
+	"json-parse": &singleArgFunction{
+		// (json-parse <string>)
+		jsonParseFn,
+	},
+	"json-stringify": &multiArgFunction{
+		// (json-stringify <expr> [<pretty>])
+		jsonStringifyFn,
+	},
+	"read-json-file": &singleArgFunction{
+		// (read-json-file <filename>)
+		readJSONFileFn,
+	},
+	"write-json-file": &multiArgFunction{
+		// (write-json-file <filename> <expr> [<pretty>])
+		writeJSONFileFn,
+	},
+
+	// time
+	"now": &noArgFunction{
+		// (now)
+		func() (Any, error) {
+			return Time{Time: time.Now()}, nil
+		},
+	},
+	"unix-time": &multiArgFunction{
+		// (unix-time [<time>])
+		unixTimeFn,
+	},
+	"from-unix-time": &singleArgFunction{
+		// (from-unix-time <seconds>)
+		fromUnixTimeFn,
+	},
+	"parse-time": &multiArgFunction{
+		// (parse-time <layout> <string> [<time zone>])
+		parseTimeFn,
+	},
+	"format-time": &multiArgFunction{
+		// (format-time <time> <layout>)
+		formatTimeFn,
+	},
+	"duration": &singleArgFunction{
+		// (duration <string or seconds>)
+		durationFn,
+	},
+	"+duration": &multiArgFunction{
+		// (+duration <time or duration> <duration>...)
+		addDurationFn,
+	},
+	"time-diff": &multiArgFunction{
+		// (time-diff <time> <time>)
+		timeDiffFn,
+	},
+	"duration->seconds": &singleArgFunction{
+		// (duration->seconds <duration>)
+		func(obj Any) (Any, error) {
+			d, ok := obj.(Duration)
+			if !ok {
+				return nil, &ErrWrongType{obj}
+			}
+			return Float(time.Duration(d).Seconds()), nil
+		},
+	},
+	"in-tz": &multiArgFunction{
+		// (in-tz <time> <time zone>)
+		inTimeZoneFn,
+	},
+	"sleep": &singleArgFunction{
+		// (sleep <duration or seconds>)
+		sleepFn,
+	},
+	"time?": &singleArgFunction{
+		// (time? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Time)
+			return Bool(ok), nil
+		},
+	},
+	"duration?": &singleArgFunction{
+		// (duration? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Duration)
+			return Bool(ok), nil
+		},
+	},
 
 	// utils
+	"exit": &multiArgFunction{
+		// (exit [<int>])
+		func(objs []Any) (Any, error) {
+			switch len(objs) {
+			case 0:
+				return nil, &ErrExit{0}
+			case 1:
+				code, ok := objs[0].(Int)
+				if !ok {
+					return nil, &ErrWrongType{objs[0]}
+				}
+				return nil, &ErrExit{code}
+			default:
+				return nil, &ErrNumArgs{len(objs)}
+			}
+		},
+	},
 	"error": &multiArgFunction{
 		// (error <expr>...)
 		func(objs []Any) (Any, error) {
-			str, err := toString(objs, "")
+			str, err := toText(objs, "")
 			if err != nil {
 				return nil, fmt.Errorf("failed parsing error message: %s", err)
 			}
@@ -447,34 +1026,34 @@ var buildins = map[Symbol]Any{
 		// (< <expr>...)
 		ltFn,
 	},
-	"+": &multiArgFloatFunction{
+	"+": &multiArgNumericFunction{
 		// (+ <expr>...)
-		func(x, y Float) Float { return x + y },
+		addOp,
 		0,
 	},
-	"-": &multiArgFloatFunction{
+	"-": &multiArgNumericFunction{
 		// (- <expr>...)
-		func(x, y Float) Float { return x - y },
+		subOp,
 		0,
 	},
-	"*": &multiArgFloatFunction{
+	"*": &multiArgNumericFunction{
 		// (* <expr>...)
-		func(x, y Float) Float { return x * y },
+		mulOp,
 		1,
 	},
-	"/": &multiArgFloatFunction{
+	"/": &multiArgNumericFunction{
 		// (/ <expr>...)
-		func(x, y Float) Float { return x / y },
+		divOp,
 		1,
 	},
-	"%": &multiArgFloatFunction{
+	"%": &multiArgNumericFunction{
 		// (% <expr>...)
-		math.Mod,
+		modOp,
 		1,
 	},
-	"pow": &multiArgFloatFunction{
+	"pow": &multiArgNumericFunction{
 		// (pow <expr>...)
-		math.Pow,
+		powOp,
 		1,
 	},
 	"rem": &multiArgFloatFunction{
@@ -488,8 +1067,10 @@ var buildins = map[Symbol]Any{
 			switch obj := obj.(type) {
 			case Float:
 				return math.IsInf(obj, 0), nil
-			case Int:
+			case Int, BigInt, Rational:
 				return false, nil
+			case Complex:
+				return cmplx.IsInf(complex128(obj)), nil
 			default:
 				return nil, &ErrNaN{obj}
 			}
@@ -501,36 +1082,66 @@ var buildins = map[Symbol]Any{
 			switch obj := obj.(type) {
 			case Float:
 				return math.IsNaN(obj), nil
-			case Int:
+			case Int, BigInt, Rational:
 				return false, nil
+			case Complex:
+				return cmplx.IsNaN(complex128(obj)), nil
 			default:
 				return nil, &ErrNaN{obj}
 			}
 		},
 	},
-	"sqrt": &singleArgFloatFunction{
+	"complex": &multiArgFunction{
+		// (complex <expr> <expr>)
+		complexFn,
+	},
+	"real": &singleArgFunction{
+		// (real <expr>)
+		vectorized(realFn),
+	},
+	"imag": &singleArgFunction{
+		// (imag <expr>)
+		vectorized(imagFn),
+	},
+	"abs": &singleArgFunction{
+		// (abs <expr>)
+		vectorized(absFn),
+	},
+	"phase": &singleArgFunction{
+		// (phase <expr>)
+		vectorized(phaseFn),
+	},
+	"conjugate": &singleArgFunction{
+		// (conjugate <expr>)
+		vectorized(conjugateFn),
+	},
+	"sqrt": &singleArgNumericFunction{
 		// (sqrt <expr>)
 		math.Sqrt,
+		cmplx.Sqrt,
 	},
 	"cbrt": &singleArgFloatFunction{
 		// (cbrt <expr>)
 		math.Cbrt,
 	},
-	"log": &singleArgFloatFunction{
+	"log": &singleArgNumericFunction{
 		// (log <expr>)
 		math.Log,
+		cmplx.Log,
 	},
 	"log2": &singleArgFloatFunction{
 		// (log2 <expr>)
 		math.Log2,
 	},
-	"log10": &singleArgFloatFunction{
+	"log10": &singleArgNumericFunction{
 		// (log10 <expr>)
 		math.Log10,
+		cmplx.Log10,
 	},
-	"exp": &singleArgFloatFunction{
+	"exp": &singleArgNumericFunction{
 		// (exp <expr>)
 		math.Exp,
+		cmplx.Exp,
 	},
 	"expm1": &singleArgFloatFunction{
 		// (expm1 <expr>)
@@ -544,41 +1155,50 @@ var buildins = map[Symbol]Any{
 		// (ceil <expr>)
 		math.Ceil,
 	},
-	"sin": &singleArgFloatFunction{
+	"sin": &singleArgNumericFunction{
 		// (sin <expr>)
 		math.Sin,
+		cmplx.Sin,
 	},
-	"cos": &singleArgFloatFunction{
+	"cos": &singleArgNumericFunction{
 		// (cos <expr>)
 		math.Cos,
+		cmplx.Cos,
 	},
-	"tan": &singleArgFloatFunction{
+	"tan": &singleArgNumericFunction{
 		// (tan <expr>)
 		math.Tan,
+		cmplx.Tan,
 	},
-	"asin": &singleArgFloatFunction{
+	"asin": &singleArgNumericFunction{
 		// (asin <expr>)
 		math.Asin,
+		cmplx.Asin,
 	},
-	"acos": &singleArgFloatFunction{
+	"acos": &singleArgNumericFunction{
 		// (acos <expr>)
 		math.Acos,
+		cmplx.Acos,
 	},
-	"atan": &singleArgFloatFunction{
+	"atan": &singleArgNumericFunction{
 		// (atan <expr>)
 		math.Atan,
+		cmplx.Atan,
 	},
-	"sinh": &singleArgFloatFunction{
+	"sinh": &singleArgNumericFunction{
 		// (sinh <expr>)
 		math.Sinh,
+		cmplx.Sinh,
 	},
-	"cosh": &singleArgFloatFunction{
+	"cosh": &singleArgNumericFunction{
 		// (cosh <expr>)
 		math.Cosh,
+		cmplx.Cosh,
 	},
-	"tanh": &singleArgFloatFunction{
+	"tanh": &singleArgNumericFunction{
 		// (tanh <expr>)
 		math.Tanh,
+		cmplx.Tanh,
 	},
 	"erf": &singleArgFloatFunction{
 		// (erf <expr>)
@@ -625,4 +1245,193 @@ var buildins = map[Symbol]Any{
 		func(x, y Int) Int { return x % y },
 		1,
 	},
+
+	// statistics, the functions accept lists or iterators
+	"sum": &singleArgFunction{
+		// (sum <list>)
+		sumFn,
+	},
+	"cumsum": &singleArgFunction{
+		// (cumsum <list>)
+		cumsumFn,
+	},
+	"mean": &singleArgFunction{
+		// (mean <list>)
+		meanFn,
+	},
+	"median": &singleArgFunction{
+		// (median <list>)
+		medianFn,
+	},
+	"quantile": &multiArgFunction{
+		// (quantile <list> <expr | list>)
+		quantileFn,
+	},
+	"variance": &singleArgFunction{
+		// (variance <list>)
+		varianceFn,
+	},
+	"stdev": &singleArgFunction{
+		// (stdev <list>)
+		stdevFn,
+	},
+	"min": &multiArgFunction{
+		// (min <list>)
+		// (min <expr>...)
+		minFn,
+	},
+	"max": &multiArgFunction{
+		// (max <list>)
+		// (max <expr>...)
+		maxFn,
+	},
+	"dot": &multiArgFunction{
+		// (dot <list> <list>)
+		dotFn,
+	},
+	"linspace": &multiArgFunction{
+		// (linspace <start> <stop> <int>)
+		linspaceFn,
+	},
+
+	// matrices
+	"matrix": &multiArgFunction{
+		// (matrix <list of rows>)
+		// (matrix <rows> <cols> [<list>])
+		matrixFn,
+	},
+	"matrix?": &singleArgFunction{
+		// (matrix? <expr>)
+		func(obj Any) (Any, error) {
+			_, ok := obj.(Matrix)
+			return ok, nil
+		},
+	},
+	"matrix->list": &singleArgFunction{
+		// (matrix->list <matrix>)
+		func(obj Any) (Any, error) {
+			m, err := getMatrix(obj)
+			if err != nil {
+				return nil, err
+			}
+			return matrixToList(m), nil
+		},
+	},
+	"identity": &singleArgFunction{
+		// (identity <int>)
+		identityFn,
+	},
+	"dims": &singleArgFunction{
+		// (dims <matrix>)
+		func(obj Any) (Any, error) {
+			m, err := getMatrix(obj)
+			if err != nil {
+				return nil, err
+			}
+			return List{m.Rows, m.Cols}, nil
+		},
+	},
+	"mat-get": &multiArgFunction{
+		// (mat-get <matrix> <row> <col>)
+		matGetFn,
+	},
+	"mat-row": &multiArgFunction{
+		// (mat-row <matrix> <row>)
+		matRowFn,
+	},
+	"mat-col": &multiArgFunction{
+		// (mat-col <matrix> <col>)
+		matColFn,
+	},
+	"submatrix": &multiArgFunction{
+		// (submatrix <matrix> <(start end) | nil> <(start end) | nil>)
+		submatrixFn,
+	},
+	"mat*": &multiArgFunction{
+		// (mat* <matrix | list | expr>...)
+		matMulFn,
+	},
+	"transpose": &singleArgFunction{
+		// (transpose <matrix>)
+		func(obj Any) (Any, error) {
+			m, err := getMatrix(obj)
+			if err != nil {
+				return nil, err
+			}
+			return transpose(m), nil
+		},
+	},
+	"det": &singleArgFunction{
+		// (det <matrix>)
+		detFn,
+	},
+	"inverse": &singleArgFunction{
+		// (inverse <matrix>)
+		inverseFn,
+	},
+	"solve": &multiArgFunction{
+		// (solve <matrix> <matrix | list>)
+		solveFn,
+	},
+
+	// bitwise operations, like the int+ family, work on fixed-size integers
+	"bit-and": &multiArgIntFunction{
+		// (bit-and <expr>...)
+		func(x, y Int) Int { return x & y },
+		-1,
+	},
+	"bit-or": &multiArgIntFunction{
+		// (bit-or <expr>...)
+		func(x, y Int) Int { return x | y },
+		0,
+	},
+	"bit-xor": &multiArgIntFunction{
+		// (bit-xor <expr>...)
+		func(x, y Int) Int { return x ^ y },
+		0,
+	},
+	"bit-not": &singleArgFunction{
+		// (bit-not <expr>)
+		func(obj Any) (Any, error) {
+			x, ok := obj.(Int)
+			if !ok {
+				return nil, fmt.Errorf("%v (%T) is not an int", obj, obj)
+			}
+			return ^x, nil
+		},
+	},
+	"shift-left": &multiArgFunction{
+		// (shift-left <expr> <int>)
+		func(objs []Any) (Any, error) {
+			return shiftFn(objs, func(x Int, n uint) Int { return x << n })
+		},
+	},
+	"shift-right": &multiArgFunction{
+		// (shift-right <expr> <int>)
+		func(objs []Any) (Any, error) {
+			return shiftFn(objs, func(x Int, n uint) Int { return x >> n })
+		},
+	},
+	"bit-count": &singleArgFunction{
+		// (bit-count <expr>)
+		func(obj Any) (Any, error) {
+			x, ok := obj.(Int)
+			if !ok {
+				return nil, fmt.Errorf("%v (%T) is not an int", obj, obj)
+			}
+			return Int(bits.OnesCount64(uint64(x))), nil
+		},
+	},
+	"int->hex": &singleArgFunction{
+		// (int->hex <expr>)
+		func(obj Any) (Any, error) {
+			return formatInt(obj, 16, "0x")
+		},
+	},
+	"int->bin": &singleArgFunction{
+		// (int->bin <expr>)
+		func(obj Any) (Any, error) {
+			return formatInt(obj, 2, "0b")
+		},
+	},
 }
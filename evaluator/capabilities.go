@@ -0,0 +1,52 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// Capability allows using the builtins that access the host system,
+// so that the programs embedding gol can sandbox the scripts
+type Capability uint
+
+const (
+	// AllowOS enables access to the environment variables, working directory,
+	// and process metadata
+	AllowOS Capability = 1 << iota
+	// AllowExec enables running the subprocesses
+	AllowExec
+)
+
+// AllowAll enables all the capabilities
+const AllowAll = AllowOS | AllowExec
+
+// capabilityBuildins are the builtins available only with the capability
+var capabilityBuildins = map[Capability]map[Symbol]Any{
+	AllowOS:   osBuildins,
+	AllowExec: execBuildins,
+}
+
+// disabledFunction replaces the builtins that are not allowed
+type disabledFunction struct {
+	name Symbol
+}
+
+func (f *disabledFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	return nil, fmt.Errorf("%s is disabled for this evaluator", f.name)
+}
+
+// capabilitiesEnv returns the environment with the builtins for the capabilities
+func capabilitiesEnv(caps Capability, parent *environment.Env) *environment.Env {
+	env := environment.NewEnv(parent)
+	for capability, objs := range capabilityBuildins {
+		for name, obj := range objs {
+			if caps&capability != 0 {
+				env.Set(name, obj)
+			} else {
+				env.Set(name, &disabledFunction{name})
+			}
+		}
+	}
+	return env
+}
@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"errors"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// Continuations are escape-only: they can be used to return early
+// from the call/cc (or let/ec) that created them, but not to re-enter it
+// after it returned. Calling a continuation unwinds the evaluation like an
+// error does, until it is caught by the form that created the continuation.
+
+type continuation struct {
+	active bool
+}
+
+func (k *continuation) Eval(args []Any, env *environment.Env) (Any, error) {
+	if len(args) > 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	objs, err := evalAll(args, env)
+	if err != nil {
+		return nil, err
+	}
+	if !k.active {
+		return nil, errors.New("continuation called outside of its extent")
+	}
+	return nil, &escape{k, last(objs)}
+}
+
+func (k *continuation) String() string {
+	return "<continuation>"
+}
+
+// escape carries the value passed to the continuation up the call stack
+type escape struct {
+	k   *continuation
+	val Any
+}
+
+func (e *escape) Error() string {
+	return "continuation called outside of its extent"
+}
+
+// catch stops the escape if it was started by the k continuation
+func (k *continuation) catch(val Any, err error) (Any, error) {
+	return catchEscapes([]*continuation{k}, val, err)
+}
+
+// catchEscapes stops the escape if it was started by any of the continuations,
+// the continuations cannot be called afterwards
+func catchEscapes(ks []*continuation, val Any, err error) (Any, error) {
+	for _, k := range ks {
+		k.active = false
+	}
+
+	var esc *escape
+	if errors.As(err, &esc) {
+		for _, k := range ks {
+			if esc.k == k {
+				return esc.val, nil
+			}
+		}
+	}
+	return val, err
+}
+
+// escapeForm creates the continuation and returns the expression to be evaluated
+// in the tail position, the eval loop catches the escapes of the continuation
+// when it finishes, so the loops using call/cc or let/ec stay tail call optimized
+type escapeForm interface {
+	function
+	Enter([]Any, *environment.Env) (Any, *environment.Env, *continuation, error)
+}
+
+type escapeFunction struct {
+	fn func([]Any, *environment.Env) (Any, *environment.Env, *continuation, error)
+}
+
+func (f *escapeFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	expr, env, k, err := f.Enter(args, env)
+	if k == nil {
+		return nil, err
+	}
+	if err != nil {
+		return k.catch(nil, err)
+	}
+	return k.catch(eval(expr, env))
+}
+
+func (f *escapeFunction) Enter(args []Any, env *environment.Env) (Any, *environment.Env, *continuation, error) {
+	return f.fn(args, env)
+}
+
+func callCCFn(args []Any, env *environment.Env) (Any, *environment.Env, *continuation, error) {
+	if len(args) != 1 {
+		return nil, env, nil, &ErrNumArgs{len(args)}
+	}
+	fn, err := getFunction(args[0], env)
+	if err != nil {
+		return nil, env, nil, err
+	}
+	k := &continuation{true}
+	return List{fn, k}, env, k, nil
+}
+
+func letEcFn(args []Any, env *environment.Env) (Any, *environment.Env, *continuation, error) {
+	if len(args) < 2 {
+		return nil, env, nil, &ErrNumArgs{len(args)}
+	}
+	name, ok := args[0].(Symbol)
+	if !ok {
+		return nil, env, nil, &ErrWrongType{args[0]}
+	}
+
+	k := &continuation{true}
+	localEnv := environment.NewEnv(env)
+	localEnv.Set(name, k)
+
+	body := args[1:]
+	_, err := evalAll(exceptLast(body), localEnv)
+	return last(body), localEnv, k, err
+}
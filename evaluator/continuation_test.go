@@ -0,0 +1,97 @@
+package evaluator
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestContinuations(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(call/cc (fn (k) 42))`, Int(42)},
+		{`(call/cc (fn (k) (k 42) (error "not reached")))`, Int(42)},
+		{`(call/cc (fn (k) (k)))`, nil},
+		{`(call-with-current-continuation (fn (k) (int+ 1 (k 2))))`, Int(2)},
+		{`(let/ec k (k "early") "late")`, String("early")},
+		{`(let/ec k "late")`, String("late")},
+		{`(int+ 1 (let/ec k (int+ 10 (k 2))))`, Int(3)},
+		// early exit from nested map
+		{`(def (find-first pred lst)
+			(call/cc (fn (return)
+				(map (fn (x) (if (pred x) (return x) nil)) lst)
+				nil)))
+		  (find-first (fn (x) (> x 2)) '(1 2 3 4 5))`, Int(3)},
+		{`(call/cc (fn (k)
+			(map (fn (row)
+				(map (fn (x) (cond
+					((= x 5) (k (list "found" x)))
+					(true x)))
+				row))
+			'((1 2) (3 4) (5 6)))))`, List{String("found"), Int(5)}},
+		// escaping from the inner continuation only
+		{`(let/ec outer
+			(list "outer" (let/ec inner (outer (inner "inner")))))`, List{String("outer"), String("inner")}},
+		{`(let/ec outer
+			(list "outer" (let/ec inner (inner (outer "escaped")))))`, String("escaped")},
+		{`(let/ec k (or false (k "from or")))`, String("from or")},
+		// escaping from the tail position of cond and if
+		{`(call/cc (fn (k) (cond ((= 1 2) "no") (true (k "from cond")))))`, String("from cond")},
+		{`(let/ec k (int+ 1 2) (if true (k "from if") "not this"))`, String("from if")},
+		{`(list (call/cc (fn (k) (if false 1 (k 2)))) 3)`, List{Int(2), Int(3)}},
+		{`(apply call/cc (list (fn (k) (k 1) 2)))`, Int(1)},
+		{`(map (fn (x) (let/ec k (if (> x 1) (k "big") x))) '(1 2))`, List{Int(1), String("big")}},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestContinuationsEscapeTailRecursion(t *testing.T) {
+	code := `
+	(def (loop n k)
+		(if (= n 0)
+			(k "done")
+			(loop (int- n 1) k)))
+	(call/cc (fn (k) (loop 100000 k)))
+	`
+
+	e := NewEvaluator()
+	results, err := e.EvalString(code)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if result := last(results); result != String("done") {
+		t.Errorf("expected %q, got %v", "done", result)
+	}
+}
+
+func TestContinuationsInTailPosition(t *testing.T) {
+	// the loop would overflow the limited stack if call/cc and let/ec
+	// were not evaluated in the tail position
+	defer debug.SetMaxStack(debug.SetMaxStack(8 << 20))
+
+	var testCases = []evalTestCase{
+		{`(def (count n)
+			(call/cc (fn (k)
+				(cond ((= n 0) (k "done"))
+					  (true (count (int- n 1)))))))
+		  (count 100000)`, String("done")},
+		{`(def (count n)
+			(let/ec k
+				(if (= n 0) (k "done") (count (int- n 1)))))
+		  (count 100000)`, String("done")},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestContinuationsOutsideOfExtent(t *testing.T) {
+	e := NewEvaluator()
+
+	_, err := e.EvalString(`
+	(def saved nil)
+	(call/cc (fn (k) (set! saved k)))
+	(saved 1)
+	`)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -3,6 +3,7 @@ package evaluator
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"strings"
 
@@ -97,26 +98,74 @@ func mapFn(args []Any, env *environment.Env) (Any, error) {
 		return nil, &ErrNumArgs{len(args)}
 	}
 
+	fn, err := getFunction(args[0], env)
+	if err != nil {
+		return nil, err
+	}
 	obj, err := eval(args[1], env)
 	if err != nil {
 		return nil, err
 	}
-	l, ok := obj.(List)
-	if !ok {
+
+	switch seq := obj.(type) {
+	case List:
+		var out List
+		for _, arg := range seq {
+			res, err := callFunction(fn.(function), []Any{arg}, env)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, res)
+		}
+		return out, nil
+	case iterator:
+		return mapIterator(fn.(function), seq, env), nil
+	default:
 		return nil, &ErrWrongType{args[1]}
 	}
+}
 
-	expr := List{args[0]}
-	var out List
-	for _, arg := range l {
-		res, err := eval(append(expr, arg), env)
-		if err != nil {
-			return nil, err
+func filterFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 2 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+
+	fn, err := getFunction(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := eval(args[1], env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch seq := obj.(type) {
+	case List:
+		out := List{}
+		for _, arg := range seq {
+			keep, err := callFunction(fn.(function), []Any{arg}, env)
+			if err != nil {
+				return nil, err
+			}
+			if isTrue(keep) {
+				out = append(out, arg)
+			}
 		}
-		out = append(out, res)
+		return out, nil
+	case iterator:
+		return filterIterator(fn.(function), seq, env), nil
+	default:
+		return nil, &ErrWrongType{args[1]}
 	}
+}
 
-	return out, nil
+// call the function with the arguments that were already evaluated
+func callFunction(fn function, args []Any, env *environment.Env) (Any, error) {
+	var quoted []Any
+	for _, arg := range args {
+		quoted = append(quoted, List{Symbol("quote"), arg})
+	}
+	return fn.Eval(quoted, env)
 }
 
 func quasiquote(arg Any, env *environment.Env) (Any, error) {
@@ -151,22 +200,36 @@ func quasiquote(arg Any, env *environment.Env) (Any, error) {
 }
 
 func nthFn(args []Any) (Any, error) {
+	var n int
+	switch arg := args[1].(type) {
+	case Int:
+		n = arg
+	case Float:
+		n = int(arg)
+	default:
+		return nil, &ErrWrongType{args[1]}
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("negative index: %d", n)
+	}
+
 	switch l := args[0].(type) {
 	case List:
-		var n int
-		switch arg := args[1].(type) {
-		case Int:
-			n = arg
-		case Float:
-			n = int(arg)
-		default:
-			return nil, &ErrWrongType{args[1]}
-		}
-
 		if n < len(l) {
 			return l[n], nil
 		}
 		return nil, fmt.Errorf("arrempting to access %d element of %d", n, len(l))
+	case String:
+		// strings are indexed by characters, not bytes
+		text, err := l.Unquote()
+		if err != nil {
+			return nil, err
+		}
+		runes := []rune(text.Raw())
+		if n < len(runes) {
+			return Char(runes[n]), nil
+		}
+		return nil, fmt.Errorf("arrempting to access %d element of %d", n, len(runes))
 	default:
 		return nil, &ErrWrongType{args[0]}
 	}
@@ -234,8 +297,11 @@ func orFn(args []Any, env *environment.Env) (Any, error) {
 	}
 	for _, arg := range args {
 		obj, err := eval(arg, env)
+		if err != nil {
+			return nil, err
+		}
 		if isTrue(obj) {
-			return Bool(true), err
+			return Bool(true), nil
 		}
 	}
 	return Bool(false), nil
@@ -255,65 +321,118 @@ func equalFn(args []Any, env *environment.Env) (Any, error) {
 		if err != nil {
 			return nil, err
 		}
+		if !isEqual(first, second) {
+			return Bool(false), nil
+		}
+	}
 
-		switch first := first.(type) {
-		case Bool:
-			second, ok := second.(Bool)
-			if !ok || first != second {
-				return Bool(false), nil
-			}
-		case Int:
-			switch second := second.(type) {
-			case Int:
-				if first != second {
-					return Bool(false), nil
-				}
-			case Float:
-				if Float(first) != second {
-					return Bool(false), nil
-				}
-			default:
-				return Bool(false), nil
-			}
-		case Float:
-			switch second := second.(type) {
-			case Float:
-				if first != second {
-					return Bool(false), nil
-				}
-			case Int:
-				if first != Float(second) {
-					return Bool(false), nil
-				}
-			default:
-				return Bool(false), nil
+	return Bool(true), nil
+}
+
+func isEqual(first, second Any) bool {
+	switch first := first.(type) {
+	case nil:
+		return second == nil
+	case Bool:
+		second, ok := second.(Bool)
+		return ok && first == second
+	case Int, BigInt, Rational, Float, Complex:
+		return numbersEqual(first, second)
+	case String:
+		second, ok := second.(String)
+		if !ok {
+			return false
+		}
+		if first == second {
+			return true
+		}
+		// the same text can be escaped in different ways, e.g. "\t" and "\x09"
+		x, err1 := first.Unquote()
+		y, err2 := second.Unquote()
+		return err1 == nil && err2 == nil && x == y
+	case Char:
+		second, ok := second.(Char)
+		return ok && first == second
+	case Regex:
+		second, ok := second.(Regex)
+		return ok && first.String() == second.String()
+	case Symbol:
+		second, ok := second.(Symbol)
+		return ok && first == second
+	case List:
+		second, ok := second.(List)
+		if !ok || len(first) != len(second) {
+			return false
+		}
+		for i := range first {
+			if !isEqual(first[i], second[i]) {
+				return false
 			}
-		case String:
-			second, ok := second.(String)
-			if !ok || first != second {
-				return Bool(false), nil
+		}
+		return true
+	case Map:
+		second, ok := second.(Map)
+		if !ok || len(first) != len(second) {
+			return false
+		}
+		for k, v := range first {
+			w, ok := second[k]
+			if !ok || !isEqual(v, w) {
+				return false
 			}
-		case function:
-			second, ok := second.(function)
-			if !ok || first != second {
-				return Bool(false), nil
+		}
+		return true
+	case Time:
+		second, ok := second.(Time)
+		return ok && first.Equal(second.Time)
+	case Duration:
+		second, ok := second.(Duration)
+		return ok && first == second
+	case Matrix:
+		second, ok := second.(Matrix)
+		if !ok || first.Rows != second.Rows || first.Cols != second.Cols {
+			return false
+		}
+		for i := range first.Data {
+			if first.Data[i] != second.Data[i] {
+				return false
 			}
-		default:
-			if !cmp.Equal(first, second) {
-				return Bool(false), nil
+		}
+		return true
+	case Record:
+		second, ok := second.(Record)
+		if !ok || first.Type != second.Type {
+			return false
+		}
+		for i := range first.Values {
+			if !isEqual(first.Values[i], second.Values[i]) {
+				return false
 			}
 		}
+		return true
+	case *fileHandle:
+		second, ok := second.(*fileHandle)
+		return ok && first == second
+	case function:
+		second, ok := second.(function)
+		return ok && first == second
+	case iterator:
+		second, ok := second.(iterator)
+		return ok && first == second
+	default:
+		return cmp.Equal(first, second)
 	}
-
-	return Bool(true), nil
 }
 
 func parseStringFn(obj Any) (Any, error) {
-	code, ok := obj.(String)
-	if !ok {
+	if _, ok := obj.(String); !ok {
 		return nil, &ErrWrongType{obj}
 	}
-	reader := strings.NewReader(string(code))
+	code, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	reader := strings.NewReader(code)
 	expr, err := parser.Parse(reader)
 	if err != nil {
 		return nil, err
@@ -331,14 +450,16 @@ func parseStringFn(obj Any) (Any, error) {
 
 func toInt(obj Any) (Any, error) {
 	switch obj := obj.(type) {
-	case Int:
+	case Int, BigInt:
 		return obj, nil
+	case Rational:
+		return normalize(new(big.Int).Quo(obj.Num(), obj.Denom())), nil
 	case Float:
 		return Int(obj), nil
 	case String:
 		switch {
 		case parser.IsInt(string(obj)):
-			return parser.ParseInt(string(obj))
+			return parser.ParseInteger(string(obj))
 		case parser.IsFloat(string(obj)):
 			f, err := parser.ParseFloat(string(obj))
 			if err != nil {
@@ -355,10 +476,8 @@ func toInt(obj Any) (Any, error) {
 
 func toFloat(obj Any) (Any, error) {
 	switch obj := obj.(type) {
-	case Float:
-		return obj, nil
-	case Int:
-		return Float(obj), nil
+	case Float, Int, BigInt, Rational:
+		return floatValue(obj)
 	case String:
 		switch {
 		case parser.IsFloat(string(obj)):
@@ -384,6 +503,8 @@ func printEnv(env *environment.Env, depth int) {
 	fmt.Printf("%d: { %v }\n", depth, strings.Join(out, ", "))
 }
 
+// toString joins the strings, characters, and representations
+// of other values as the escaped text
 func toString(objs []Any, sep String) (string, error) {
 	if len(objs) == 0 {
 		return "", &ErrNumArgs{len(objs)}
@@ -394,8 +515,10 @@ func toString(objs []Any, sep String) (string, error) {
 		switch obj := obj.(type) {
 		case String:
 			str = append(str, obj.Raw())
+		case Char:
+			str = append(str, newText(string(obj)).Raw())
 		default:
-			str = append(str, fmt.Sprintf("%v", obj))
+			str = append(str, newText(fmt.Sprintf("%v", obj)).Raw())
 		}
 	}
 	return strings.Join(str, string(sep)), nil
@@ -405,12 +528,15 @@ func writeToFileFn(objs []Any) (Any, error) {
 	if len(objs) != 2 {
 		return nil, &ErrNumArgs{len(objs)}
 	}
-	fileName, ok := objs[0].(String)
-	if !ok {
+	if _, ok := objs[0].(String); !ok {
 		return nil, &ErrWrongType{objs[0]}
 	}
+	fileName, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
 
-	file, err := os.OpenFile(string(fileName), os.O_APPEND|os.O_WRONLY, 0644)
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return nil, err
 	}
@@ -419,7 +545,10 @@ func writeToFileFn(objs []Any) (Any, error) {
 	var str string
 	switch obj := objs[1].(type) {
 	case String:
-		str = obj.Raw()
+		str, err = getText(obj)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		str = fmt.Sprintf("%v", obj)
 	}
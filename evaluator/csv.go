@@ -0,0 +1,298 @@
+package evaluator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// The rows are read as lists of strings, or as maps when the :header
+// option is used, so that the values are keyed by the column names.
+
+var csvReadOptions = []Symbol{":delimiter", ":header", ":comment", ":lazy-quotes", ":trim-space"}
+
+func getRune(obj Any) (rune, error) {
+	switch obj := obj.(type) {
+	case Char:
+		return rune(obj), nil
+	case String:
+		str, err := getText(obj)
+		if err != nil {
+			return 0, err
+		}
+		if utf8.RuneCountInString(str) == 1 {
+			r, _ := utf8.DecodeRuneInString(str)
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("%v (%T) is not a single character", obj, obj)
+}
+
+// csvRows reads the rows one by one
+type csvRows struct {
+	reader    *csv.Reader
+	useHeader bool
+	header    []string
+}
+
+func newCSVRows(r io.Reader, objs []Any) (*csvRows, error) {
+	opts, err := getOptions(objs, csvReadOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	// rows can have different lengths
+	reader.FieldsPerRecord = -1
+
+	if obj, ok := opts[":delimiter"]; ok {
+		if reader.Comma, err = getRune(obj); err != nil {
+			return nil, err
+		}
+	}
+	if obj, ok := opts[":comment"]; ok {
+		if reader.Comment, err = getRune(obj); err != nil {
+			return nil, err
+		}
+	}
+	reader.LazyQuotes = isTrue(opts[":lazy-quotes"])
+	reader.TrimLeadingSpace = isTrue(opts[":trim-space"])
+
+	return &csvRows{reader: reader, useHeader: isTrue(opts[":header"])}, nil
+}
+
+func (r *csvRows) Next() (Any, bool, error) {
+	if r.useHeader && r.header == nil {
+		header, err := r.reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return nil, false, err
+		}
+		r.header = header
+	}
+
+	record, err := r.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = nil
+		}
+		return nil, false, err
+	}
+
+	if !r.useHeader {
+		row := List{}
+		for _, field := range record {
+			row = append(row, newText(field))
+		}
+		return row, true, nil
+	}
+
+	if len(record) > len(r.header) {
+		return nil, false, fmt.Errorf("record %q has more fields than the header", record)
+	}
+	row := make(Map, len(record))
+	for i, field := range record {
+		row[newText(r.header[i])] = newText(field)
+	}
+	return row, true, nil
+}
+
+func (r *csvRows) String() string {
+	return "<iterator>"
+}
+
+func csvReadFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	rows, err := newCSVRows(file, objs[1:])
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows)
+}
+
+func csvParseFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	str, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	rows, err := newCSVRows(strings.NewReader(str), objs[1:])
+	if err != nil {
+		return nil, err
+	}
+	return collect(rows)
+}
+
+// csvRowsFn returns a lazy iterator over the rows of the file,
+// the file is closed when all the rows were read
+func csvRowsFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := newCSVRows(file, objs[1:])
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	closed := false
+	return &lazySeq{func() (Any, bool, error) {
+		if closed {
+			return nil, false, nil
+		}
+		val, ok, err := rows.Next()
+		if !ok || err != nil {
+			closed = true
+			file.Close()
+		}
+		return val, ok, err
+	}}, nil
+}
+
+// csvWriteFn writes the rows, given as a list or an iterator, to the file.
+// The rows can be lists, or maps, in such a case the columns are given
+// by the :header option, or the sorted keys of the first row.
+func csvWriteFn(objs []Any) (Any, error) {
+	if len(objs) < 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	opts, err := getOptions(objs[2:], ":delimiter", ":header", ":crlf")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows iterator
+	switch obj := objs[1].(type) {
+	case List:
+		rows = listIterator(obj)
+	case iterator:
+		rows = obj
+	default:
+		return nil, &ErrWrongType{obj}
+	}
+
+	file, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	if obj, ok := opts[":delimiter"]; ok {
+		if writer.Comma, err = getRune(obj); err != nil {
+			return nil, err
+		}
+	}
+	writer.UseCRLF = isTrue(opts[":crlf"])
+
+	var header List
+	obj, hasHeaderOpt := opts[":header"]
+	switch obj := obj.(type) {
+	case List:
+		for _, key := range obj {
+			if _, err := getKey(key); err != nil {
+				return nil, err
+			}
+		}
+		header = obj
+	case Bool, nil:
+	default:
+		return nil, &ErrWrongType{obj}
+	}
+	useHeader := isTrue(obj)
+
+	first := true
+	for {
+		row, ok, err := rows.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+
+		if first {
+			first = false
+			if m, ok := row.(Map); ok {
+				if header == nil {
+					header = sortedKeys(m)
+				}
+				// the header is written for maps, unless disabled
+				useHeader = useHeader || !hasHeaderOpt
+			}
+			if useHeader && header != nil {
+				if err := writeCSVRecord(writer, header); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		var values List
+		switch row := row.(type) {
+		case List:
+			values = row
+		case Map:
+			if header == nil {
+				return nil, fmt.Errorf("header is required for writing maps")
+			}
+			for _, key := range header {
+				values = append(values, row[key])
+			}
+		default:
+			return nil, &ErrWrongType{row}
+		}
+		if err := writeCSVRecord(writer, values); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return nil, writer.Error()
+}
+
+func writeCSVRecord(writer *csv.Writer, values List) error {
+	var record []string
+	for _, val := range values {
+		if val == nil {
+			record = append(record, "")
+			continue
+		}
+		str, err := toText([]Any{val}, "")
+		if err != nil {
+			return err
+		}
+		record = append(record, str)
+	}
+	return writer.Write(record)
+}
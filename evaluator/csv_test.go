@@ -0,0 +1,103 @@
+package evaluator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(csv-parse "a,b\n1,2\n")`, List{
+			List{String("a"), String("b")},
+			List{String("1"), String("2")},
+		}},
+		{`(csv-parse "a\tb\n1\t2\t3" :delimiter #\tab)`, List{
+			List{String("a"), String("b")},
+			List{String("1"), String("2"), String("3")},
+		}},
+		{`(csv-parse "a\tb" :delimiter "\t")`, List{
+			List{String("a"), String("b")},
+		}},
+		{`(csv-parse "a|b" :delimiter "|")`, List{
+			List{String("a"), String("b")},
+		}},
+		{`(csv-parse "name,age\nAnn,42\nBob" :header true)`, List{
+			Map{String("name"): String("Ann"), String("age"): String("42")},
+			Map{String("name"): String("Bob")},
+		}},
+		{`(csv-parse "# comment\na, b" :comment #\# :trim-space true)`, List{
+			List{String("a"), String("b")},
+		}},
+		{`(csv-parse "a,b" :header true)`, List{}},
+		{`(csv-parse "")`, List{}},
+		{`(map (fn (row) (get row "x")) (csv-parse "x\n1\n2" :header true))`, List{String("1"), String("2")}},
+		// the quoted fields are escaped like the string literals
+		{`(csv-parse "\"say \"\"hi\"\"\",\"multi\nline\"\nc:\\dir,d")`, List{
+			List{String(`say \"hi\"`), String(`multi\nline`)},
+			List{String(`c:\\dir`), String("d")},
+		}},
+		{`(upper (first (first (csv-parse "\"a\"\"b\""))))`, String(`A\"B`)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestCSVErrors(t *testing.T) {
+	var testCases = []string{
+		`(csv-parse)`,
+		`(csv-parse 42)`,
+		`(csv-parse "a" :delimiter)`,
+		`(csv-parse "a" :delimiter "ab")`,
+		`(csv-parse "a" :unknown 1)`,
+		`(csv-parse "a" 'delimiter #\|)`,
+		"(csv-parse \"a\n1,2\" :header true)",
+		`(csv-parse "a,\"b" )`,
+		`(csv-read "/this/file/does/not/exist.csv")`,
+		`(csv-write "/this/dir/does/not/exist.csv" '())`,
+	}
+
+	runErrorTests(t, testCases)
+}
+
+func TestCSVFiles(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input.csv")
+	output := filepath.Join(dir, "output.csv")
+
+	content := "name,comment\nAnn,\"hello, \"\"world\"\"\"\nBob,\"multi\nline\"\n"
+	if err := os.WriteFile(input, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var testCases = []evalTestCase{
+		{fmt.Sprintf(`(csv-read %q)`, input), List{
+			List{String("name"), String("comment")},
+			List{String("Ann"), String(`hello, \"world\"`)},
+			List{String("Bob"), String(`multi\nline`)},
+		}},
+		{fmt.Sprintf(`(take 1 (csv-rows %q :header true))`, input), List{
+			Map{String("name"): String("Ann"), String("comment"): String(`hello, \"world\"`)},
+		}},
+		{fmt.Sprintf(`(collect (map (fn (row) (first row)) (csv-rows %q)))`, input), List{
+			String("name"), String("Ann"), String("Bob"),
+		}},
+		{fmt.Sprintf(`(begin (csv-write %q (csv-read %q :header true)) (csv-read %q))`, output, input, output), List{
+			List{String("comment"), String("name")},
+			List{String(`hello, \"world\"`), String("Ann")},
+			List{String(`multi\nline`), String("Bob")},
+		}},
+		{fmt.Sprintf(`(begin (csv-write %q '((1 2.5 nil) ("x" #\y z)) :header '("a" "b" "c") :delimiter #\|) (read-file %q))`, output, output),
+			String(`a|b|c\n1|2.5|\nx|y|z`),
+		},
+		{fmt.Sprintf(`(begin (csv-write %q (list (hash-map "a" 1 "b" 2)) :header '("b")) (read-file %q))`, output, output),
+			String(`b\n2`),
+		},
+		{fmt.Sprintf(`(begin (csv-write %q (list (hash-map "a" 1)) :header false) (read-file %q))`, output, output),
+			String("1"),
+		},
+	}
+
+	runTests(testCases, t)
+}
@@ -34,6 +34,29 @@ func (e *ErrNotCallable) Error() string {
 	return fmt.Sprintf("%v (%T) is not callable", e.val, e.val)
 }
 
+// ErrParse is returned when the code cannot be parsed
+type ErrParse struct {
+	err error
+}
+
+func (e *ErrParse) Error() string {
+	return fmt.Sprintf("parsing error: %s", e.err)
+}
+
+func (e *ErrParse) Unwrap() error {
+	return e.err
+}
+
+// ErrExit is raised by exit, so that the evaluation is unwound
+// like for any other error, before terminating the program
+type ErrExit struct {
+	Code int
+}
+
+func (e *ErrExit) Error() string {
+	return fmt.Sprintf("exit with status %d", e.Code)
+}
+
 type ErrTrace struct {
 	callStack []Any
 	err       error
@@ -48,6 +71,10 @@ func (e *ErrTrace) Error() string {
 	return msg
 }
 
+func (e *ErrTrace) Unwrap() error {
+	return e.err
+}
+
 func Trace(err error, context Any) *ErrTrace {
 	switch err := err.(type) {
 	case *ErrTrace:
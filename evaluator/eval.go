@@ -12,34 +12,64 @@ type Evaluator struct {
 	env *environment.Env
 }
 
+// NewEvaluator creates the evaluator with all the capabilities enabled
 func NewEvaluator() *Evaluator {
+	return NewEvaluatorWith(AllowAll)
+}
+
+// NewEvaluatorWith creates the evaluator with only the listed capabilities
+// enabled, e.g. NewEvaluatorWith(0) disables all of them
+func NewEvaluatorWith(caps Capability) *Evaluator {
 	baseEnv := environment.NewEnv(nil)
 	baseEnv.Objects = buildins
 
+	capsEnv := capabilitiesEnv(caps, baseEnv)
+	randEnv := randomEnv(capsEnv)
+
 	// so that we shadow rather than overwrite the buildins
-	workEnv := environment.NewEnv(baseEnv)
+	workEnv := environment.NewEnv(randEnv)
 	return &Evaluator{workEnv}
 }
 
 func (e *Evaluator) EvalString(code string) ([]Any, error) {
 	expr, err := parser.Parse(strings.NewReader(code))
 	if err != nil {
-		return nil, err
+		return nil, &ErrParse{err}
 	}
 	return evalAll(expr, e.env)
 }
 
-func eval(expr Any, env *environment.Env) (Any, error) {
+// Eval evaluates the already parsed expressions
+func (e *Evaluator) Eval(exprs []Any) ([]Any, error) {
+	return evalAll(exprs, e.env)
+}
+
+// Set binds the value to the name in the evaluator's environment
+func (e *Evaluator) Set(name Symbol, val Any) {
+	e.env.Set(name, val)
+}
+
+func eval(expr Any, env *environment.Env) (result Any, err error) {
 	var (
 		newExpr Any
 		newEnv  *environment.Env
+		escapes []*continuation
 	)
 
+	defer func() {
+		if escapes != nil {
+			result, err = catchEscapes(escapes, result, err)
+		}
+	}()
+
 	for {
 		switch expr := expr.(type) {
-		case nil, Bool, Int, Float, String, function:
+		case nil, Bool, Int, BigInt, Rational, Float, Complex, String, Char, Regex, Map, Time, Duration, Matrix, Record, *fileHandle, function, iterator:
 			return expr, nil
 		case Symbol:
+			if isKeyword(expr) {
+				return expr, nil
+			}
 			return env.Get(expr)
 		case List:
 			if len(expr) == 0 {
@@ -51,6 +81,16 @@ func eval(expr Any, env *environment.Env) (Any, error) {
 			}
 
 			switch fn := fn.(type) {
+			case escapeForm:
+				var k *continuation
+				args := expr.Tail()
+				newExpr, newEnv, k, err = fn.Enter(args, env)
+				if k != nil {
+					escapes = append(escapes, k)
+				}
+				if err != nil {
+					return nil, Trace(err, expr)
+				}
 			case tailCallOptimized:
 				args := expr.Tail()
 				newExpr, newEnv, err = fn.PartialEval(args, env)
@@ -1,11 +1,18 @@
 package evaluator
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 )
 
+// big numbers have unexported fields, so cmp needs to be told how to compare them
+var numericComparers = cmp.Options{
+	cmp.Comparer(func(x, y BigInt) bool { return x.Cmp(y) == 0 }),
+	cmp.Comparer(func(x, y Rational) bool { return x.Cmp(y) == 0 }),
+}
+
 type evalTestCase struct {
 	input    string
 	expected Any
@@ -22,12 +29,24 @@ func runTests(testCases []evalTestCase, t *testing.T) {
 		if err != nil {
 			t.Errorf("unexpected error: %s", err)
 		}
-		if !cmp.Equal(result, tt.expected) {
+		if !cmp.Equal(result, tt.expected, numericComparers) {
 			t.Errorf("for %v expected: %v (%T), got: %v (%T)", tt.input, tt.expected, tt.expected, result, result)
 		}
 	}
 }
 
+func runErrorTests(t *testing.T, testCases []string) {
+	t.Helper()
+
+	for _, input := range testCases {
+		e := NewEvaluator()
+		result, err := e.EvalString(input)
+		if err == nil {
+			t.Errorf("for %s expected an error, got %v", input, result)
+		}
+	}
+}
+
 func TestEval(t *testing.T) {
 	var testCases = []evalTestCase{
 		{`nil`, nil},
@@ -52,36 +71,36 @@ func TestEval(t *testing.T) {
 			(true 2)
 			(true (error "Oh, no!")))`, Int(2)},
 		{`(quote (+ 1 2))`, List{Symbol("+"), Int(1), Int(2)}},
-		{`(quasiquote (unquote (+ 1 2)))`, Float(3)},
-		{"`,(+ 1 2)", Float(3)},
+		{`(quasiquote (unquote (+ 1 2)))`, Int(3)},
+		{"`,(+ 1 2)", Int(3)},
 		{`(def x 4)
 		  (quasiquote
-			(+ 1 2 (unquote (+ 1 2)) (unquote x)))`, List{Symbol("+"), Int(1), Int(2), Float(3), Int(4)}},
-		{"(def x 4) `(+ 1 2 ,(+ 1 2) (- ,x))", List{Symbol("+"), Int(1), Int(2), Float(3), List{Symbol("-"), Int(4)}}},
+			(+ 1 2 (unquote (+ 1 2)) (unquote x)))`, List{Symbol("+"), Int(1), Int(2), Int(3), Int(4)}},
+		{"(def x 4) `(+ 1 2 ,(+ 1 2) (- ,x))", List{Symbol("+"), Int(1), Int(2), Int(3), List{Symbol("-"), Int(4)}}},
 		{"``,x", List{Symbol("quasiquote"), List{Symbol("unquote"), Symbol("x")}}},
 		{"(def x 5) (eval (eval ```,x))", Int(5)},
-		{`(eval '(+ 2 2))`, Float(4)},
-		{`(- 7 (* 2 (+ 1 2)) 1)`, Float(0)},
-		{`(def b (+ 1 2)) b`, Float(3)},
+		{`(eval '(+ 2 2))`, Int(4)},
+		{`(- 7 (* 2 (+ 1 2)) 1)`, Int(0)},
+		{`(def b (+ 1 2)) b`, Int(3)},
 		{`(let (c 2) c)`, Int(2)},
 		{`(let (x 10)
-			(+ 5 x))`, Float(15)},
+			(+ 5 x))`, Int(15)},
 		{`(let (x 5)
 			(let (y 6)
-				 (+ x y)))`, Float(11)},
+				 (+ x y)))`, Int(11)},
 		{`(let (x 1 y (+ 2 3))
-			(+ x y))`, Float(6)},
+			(+ x y))`, Int(6)},
 		{`(let (x 1 y (+ 1 x))
-			(+ x y))`, Float(3)},
+			(+ x y))`, Int(3)},
 		{`(begin (+ 2 2)
-			     (+ 3 5))`, Float(8)},
+			     (+ 3 5))`, Int(8)},
 		{`(begin (def x 2)
-			     (+ x 4))`, Float(6)},
-		{`(eval (+ 2 2))`, Float(4)},
-		{`(eval '(+ 2 2))`, Float(4)},
-		{`(eval (list + 2 2))`, Float(4)},
+			     (+ x 4))`, Int(6)},
+		{`(eval (+ 2 2))`, Int(4)},
+		{`(eval '(+ 2 2))`, Int(4)},
+		{`(eval (list + 2 2))`, Int(4)},
 		{`((fn (a) a) 123)`, Int(123)},
-		{`((fn (a b) (+ a b)) 1 2)`, Float(3)},
+		{`((fn (a b) (+ a b)) 1 2)`, Int(3)},
 		{`((fn (x y)
 			(or (= x y)
 				(> x y)))
@@ -90,19 +109,19 @@ func TestEval(t *testing.T) {
 		{`(def x 2)
 		  ((fn (x)
 		  	(+ x 5))
-			(+ x 3))`, Float(10)},
+			(+ x 3))`, Int(10)},
 		{`(def factorial (fn (n)
 			(if (= n 1) 1
 				(int* n (factorial (int- n 1))))))
 		  (factorial 10)`, Int(3628800)},
 		{`(def foo (fn (x)
 			(fn (y) (+ x y))))
-		  ((foo 5) 6)`, Float(11)},
+		  ((foo 5) 6)`, Int(11)},
 		{`(def foo (fn (x)
 			(+ x 1)))
 		  (let (x foo)
 		  	(let (y x)
-			  (y 4)))`, Float(5)},
+			  (y 4)))`, Int(5)},
 		{`(def x nil)
 		  (let () (set! x 4))
 		  x`, Int(4)},
@@ -148,17 +167,17 @@ func TestCore(t *testing.T) {
 		{`(< 1 2 2 3)`, Bool(false)},
 		{`(> 3 2 1)`, Bool(true)},
 		{`(> 3 2 1 1)`, Bool(false)},
-		{`(eval (parse-string "(+ 2 2)"))`, Float(4)},
+		{`(eval (parse-string "(+ 2 2)"))`, Int(4)},
 		{`(parse-string (str '(1 2 "3")))`, List{Int(1), Int(2), String("3")}},
 		{`(apply (fn (x) x) '('test))`, Symbol("test")},
-		{`(apply + '(1 2 3))`, Float(6)},
+		{`(apply + '(1 2 3))`, Int(6)},
 		{`(map (fn (x) x) '(1 2 3))`, List{Int(1), Int(2), Int(3)}},
-		{`(map - '(1 2 3))`, List{Float(-1), Float(-2), Float(-3)}},
-		{`(chars "hello")`, List{String("h"), String("e"), String("l"), String("l"), String("o")}},
+		{`(map - '(1 2 3))`, List{Int(-1), Int(-2), Int(-3)}},
+		{`(chars "hello")`, List{Char('h'), Char('e'), Char('l'), Char('l'), Char('o')}},
 		{"(escaped-str \"\n\tHello World!\")", String(`\n\tHello World!`)},
 		{`(pretty-str "\n\tHello World!")`, String("\n\tHello World!")},
 		{"(pretty-str (escaped-str \"\n\tHello World!\"))", String("\n\tHello World!")},
-		{`(def (add1 x) (+ x 1)) (add1 1)`, Float(2)},
+		{`(def (add1 x) (+ x 1)) (add1 1)`, Int(2)},
 		{`(def (foo) 1) (foo)`, Int(1)},
 		{`(reverse '())`, List{}},
 		{`(reverse '(1))`, List{Int(1)}},
@@ -172,6 +191,36 @@ func TestCore(t *testing.T) {
 	runTests(testCases, t)
 }
 
+func TestChars(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`#\a`, Char('a')},
+		{`#\newline`, Char('\n')},
+		{`#\λ`, Char('λ')},
+		{`#\x3bb`, Char('λ')},
+		{`#\(`, Char('(')},
+		{`(list #\( #\))`, List{Char('('), Char(')')}},
+		{`(chars "zażółć")`, List{Char('z'), Char('a'), Char('ż'), Char('ó'), Char('ł'), Char('ć')}},
+		{`(nth "zażółć" 3)`, Char('ó')},
+		{`(char? #\a)`, Bool(true)},
+		{`(char? "a")`, Bool(false)},
+		{`(char->int #\a)`, Int(97)},
+		{`(int->char 955)`, Char('λ')},
+		{`(char-upper #\ż)`, Char('Ż')},
+		{`(char-lower #\A)`, Char('a')},
+		{`(char-alphabetic? #\a)`, Bool(true)},
+		{`(char-alphabetic? #\1)`, Bool(false)},
+		{`(char-numeric? #\1)`, Bool(true)},
+		{`(char-whitespace? #\space)`, Bool(true)},
+		{`(= #\a #\a)`, Bool(true)},
+		{`(= #\a "a")`, Bool(false)},
+		{`(str #\a #\b "c")`, String("abc")},
+		{`(str '(#\a #\space))`, String(`(#\\a #\\space)`)},
+		{`(apply str (chars "hello"))`, String("hello")},
+	}
+
+	runTests(testCases, t)
+}
+
 func TestBooleans(t *testing.T) {
 	var testCases = []evalTestCase{
 		// booleans: everything is true
@@ -200,12 +249,12 @@ func TestMath(t *testing.T) {
 		{`(+ 2 2.0)`, Float(4.0)},
 		{`(int- 3 2)`, Int(1)},
 		{`(int* 2 3)`, Int(6)},
-		{`(/ 6 3)`, Float(2)},
+		{`(/ 6 3)`, Int(2)},
 		{`(+ 2.1 4.15)`, Float(6.25)},
 		{`(- 2.1 4.0)`, Float(-1.9)},
 		{`(* 2.5 4.0)`, Float(10.0)},
 		{`(/ 10.2 5.1)`, Float(2.0)},
-		{`(+ 2 (- 4 (* 1 2)))`, Float(4)},
+		{`(+ 2 (- 4 (* 1 2)))`, Int(4)},
 		{`(int/ 100 2 5 2)`, Int(5)},
 	}
 
@@ -221,6 +270,18 @@ func TestErrorFn(t *testing.T) {
 	}
 }
 
+func TestNthErrors(t *testing.T) {
+	var testCases = []string{
+		`(nth '(1 2) -1)`,
+		`(nth '(1 2) 2)`,
+		`(nth "abc" -1)`,
+		`(nth "abc" 3)`,
+		`(nth '(1 2) "a")`,
+	}
+
+	runErrorTests(t, testCases)
+}
+
 func TestDef(t *testing.T) {
 	e := NewEvaluator()
 
@@ -312,3 +373,54 @@ func TestReverse(t *testing.T) {
 		}
 	}
 }
+
+func TestExit(t *testing.T) {
+	var testCases = []struct {
+		input    string
+		expected int
+	}{
+		{`(exit)`, 0},
+		{`(exit 3)`, 3},
+		{`(def (f x) (if (> x 0) (f (- x 1)) (exit 42))) (f 5)`, 42},
+		{`(let/ec k (map (fn (x) (exit x)) '(7 8)))`, 7},
+		{`(next (generator (fn () (exit 9))))`, 9},
+	}
+
+	for _, tt := range testCases {
+		e := NewEvaluator()
+		_, err := e.EvalString(tt.input)
+
+		var exit *ErrExit
+		if !errors.As(err, &exit) {
+			t.Errorf("for %s expected exit, got: %v", tt.input, err)
+			continue
+		}
+		if exit.Code != tt.expected {
+			t.Errorf("for %s expected exit code %d, got: %d", tt.input, tt.expected, exit.Code)
+		}
+	}
+
+	for _, input := range []string{`(exit "1")`, `(exit 1 2)`} {
+		e := NewEvaluator()
+		_, err := e.EvalString(input)
+		var exit *ErrExit
+		if err == nil || errors.As(err, &exit) {
+			t.Errorf("for %s expected an error, got: %v", input, err)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	e := NewEvaluator()
+
+	_, err := e.EvalString(`(+ 1 2`)
+	var parseErr *ErrParse
+	if !errors.As(err, &parseErr) {
+		t.Errorf("expected parsing error, got: %v", err)
+	}
+
+	_, err = e.EvalString(`(parse-string "(+ 1 2")`)
+	if err == nil || errors.As(err, &parseErr) {
+		t.Errorf("expected runtime error, got: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package evaluator
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// execBuildins require the AllowExec capability
+var execBuildins = map[Symbol]Any{
+	"run": &multiArgFunction{
+		// (run <program> [<args>] [:stdin <string>] [:env <map>] [:dir <path>])
+		runFn,
+	},
+	"sh": &multiArgFunction{
+		// (sh <command> [:stdin <string>] [:env <map>] [:dir <path>])
+		shFn,
+	},
+	"run-lines": &multiArgFunction{
+		// (run-lines <program> [<args>] [:stdin <string>] [:env <map>] [:dir <path>])
+		runLinesFn,
+	},
+	"sh-lines": &multiArgFunction{
+		// (sh-lines <command> [:stdin <string>] [:env <map>] [:dir <path>])
+		shLinesFn,
+	},
+}
+
+var execOptions = []Symbol{":stdin", ":env", ":dir"}
+
+// newCommand creates the command from the program name, optional list of arguments, and the options
+func newCommand(objs []Any) (*exec.Cmd, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	program, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var args []string
+	rest := objs[1:]
+	if len(rest) > 0 {
+		if l, ok := rest[0].(List); ok {
+			args, err = getArgs(l)
+			if err != nil {
+				return nil, err
+			}
+			rest = rest[1:]
+		}
+	}
+
+	cmd := exec.Command(program, args...)
+	err = setCommandOptions(cmd, rest)
+	return cmd, err
+}
+
+// newShellCommand creates the command evaluated by sh -c
+func newShellCommand(objs []Any) (*exec.Cmd, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	script, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("sh", "-c", script)
+	err = setCommandOptions(cmd, objs[1:])
+	return cmd, err
+}
+
+func getArgs(l List) ([]string, error) {
+	var args []string
+	for _, obj := range l {
+		str, err := toText([]Any{obj}, "")
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, str)
+	}
+	return args, nil
+}
+
+// setCommandOptions sets the standard input, working directory, and the environment
+// variables, that are added to the environment of the current process
+func setCommandOptions(cmd *exec.Cmd, objs []Any) error {
+	opts, err := getOptions(objs, execOptions...)
+	if err != nil {
+		return err
+	}
+
+	if obj, ok := opts[":stdin"]; ok {
+		str, err := getText(obj)
+		if err != nil {
+			return err
+		}
+		cmd.Stdin = strings.NewReader(str)
+	}
+	if obj, ok := opts[":dir"]; ok {
+		if cmd.Dir, err = getText(obj); err != nil {
+			return err
+		}
+	}
+	if obj, ok := opts[":env"]; ok {
+		env, err := getMap(obj)
+		if err != nil {
+			return err
+		}
+		cmd.Env = os.Environ()
+		for _, k := range sortedKeys(env) {
+			kv, err := toText([]Any{k, String("="), env[k]}, "")
+			if err != nil {
+				return err
+			}
+			cmd.Env = append(cmd.Env, kv)
+		}
+	}
+	return nil
+}
+
+// runCommand returns the map with the :exit code, :stdout, and :stderr,
+// the non-zero exit code is not an error
+func runCommand(cmd *exec.Cmd) (Any, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	code := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, err
+		}
+		code = exitErr.ExitCode()
+	}
+
+	return Map{
+		Symbol(":exit"):   Int(code),
+		Symbol(":stdout"): newText(stdout.String()),
+		Symbol(":stderr"): newText(stderr.String()),
+	}, nil
+}
+
+// commandLines returns the iterator over the lines of the output of the command,
+// it fails at the end if the command exited with a non-zero code
+func commandLines(cmd *exec.Cmd) (Any, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// do not wait forever for the output of the subprocesses that outlive the command
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	done := false
+	seq := &lazySeq{func() (Any, bool, error) {
+		if done {
+			return nil, false, nil
+		}
+		if scanner.Scan() {
+			return newText(scanner.Text()), true, nil
+		}
+
+		done = true
+		if err := scanner.Err(); err != nil {
+			cmd.Wait()
+			return nil, false, err
+		}
+		if err := cmd.Wait(); err != nil {
+			msg := strings.TrimSpace(stderr.String())
+			if msg != "" {
+				return nil, false, fmt.Errorf("%s: %s", err, msg)
+			}
+			return nil, false, err
+		}
+		return nil, false, nil
+	}}
+	// abandoned iterators kill the process and release the pipes
+	runtime.SetFinalizer(seq, func(*lazySeq) {
+		if !done {
+			cmd.Process.Kill()
+			go cmd.Wait()
+		}
+	})
+	return seq, nil
+}
+
+func runFn(objs []Any) (Any, error) {
+	cmd, err := newCommand(objs)
+	if err != nil {
+		return nil, err
+	}
+	return runCommand(cmd)
+}
+
+func shFn(objs []Any) (Any, error) {
+	cmd, err := newShellCommand(objs)
+	if err != nil {
+		return nil, err
+	}
+	return runCommand(cmd)
+}
+
+func runLinesFn(objs []Any) (Any, error) {
+	cmd, err := newCommand(objs)
+	if err != nil {
+		return nil, err
+	}
+	return commandLines(cmd)
+}
+
+func shLinesFn(objs []Any) (Any, error) {
+	cmd, err := newShellCommand(objs)
+	if err != nil {
+		return nil, err
+	}
+	return commandLines(cmd)
+}
@@ -0,0 +1,99 @@
+package evaluator
+
+import (
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestExec(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testCases = []evalTestCase{
+		{`(run "echo" '("hello" 42))`, Map{
+			Symbol(":exit"):   Int(0),
+			Symbol(":stdout"): String(`hello 42\n`),
+			Symbol(":stderr"): String(""),
+		}},
+		{`(get (run "echo") :stdout)`, String(`\n`)},
+		{`(get (sh "exit 3") :exit)`, Int(3)},
+		{`(get (sh "echo oops >&2") :stderr)`, String(`oops\n`)},
+		{`(get (run "cat" :stdin "some input") :stdout)`, String("some input")},
+		{`(get (sh "printf $GOL_X" :env (hash-map "GOL_X" "y")) :stdout)`, String("y")},
+		{`(get (run "pwd" :dir "` + filepath.ToSlash(dir) + `") :stdout)`, String(dir + `\n`)},
+		{`(collect (sh-lines "printf 'a\nb\nc'"))`, List{String("a"), String("b"), String("c")}},
+		{`(take 2 (run-lines "sh" '("-c" "echo 1 && echo 2 && echo 3")))`, List{String("1"), String("2")}},
+		{`(collect (map (fn (x) (upper x)) (run-lines "cat" :stdin "x\ny\n")))`, List{String("X"), String("Y")}},
+		// the input is unquoted, and the output is escaped like the string literals
+		{`(get (run "cat" :stdin "a\nb") :stdout)`, String(`a\nb`)},
+		{`(string-length (get (run "cat" :stdin "a\nb") :stdout))`, Int(3)},
+		{`(upper (get (sh "printf '%s' \"say \\\"hi\\\"\"") :stdout))`, String(`SAY \"HI\"`)},
+		{`(get (run "printf" '("%s" "a\\b")) :stdout)`, String(`a\\b`)},
+		{`(collect (sh-lines "echo '\"a\"'"))`, List{String(`\"a\"`)}},
+		{`(get (sh "printf \"$GOL_X\"" :env (hash-map "GOL_X" "a\"b")) :stdout)`, String(`a\"b`)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestExecErrors(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	var testCases = []string{
+		`(run)`,
+		`(run 42)`,
+		`(run "this-program-does-not-exist-42")`,
+		`(run "echo" :unknown 1)`,
+		`(run "echo" :env '(1 2))`,
+		`(sh "exit 0" :stdin 42)`,
+		`(collect (sh-lines "echo a && exit 1"))`,
+	}
+
+	runErrorTests(t, testCases)
+
+	for _, input := range []string{`(run "echo")`, `(sh "echo")`, `(run-lines "echo")`} {
+		e := NewEvaluatorWith(AllowOS)
+		result, err := e.EvalString(input)
+		if err == nil || !strings.Contains(err.Error(), "is disabled") {
+			t.Errorf("for %s expected the function to be disabled, got: %v, %v", input, result, err)
+		}
+	}
+}
+
+func TestAbandonedCommandLinesAreKilled(t *testing.T) {
+	if _, err := exec.LookPath("yes"); err != nil {
+		t.Skip("yes is not available")
+	}
+
+	cmd := exec.Command("yes")
+	seq, err := commandLines(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := seq.(iterator).Next(); !ok || err != nil {
+		t.Fatalf("expected a line, got: %v, %v", ok, err)
+	}
+	seq = nil
+
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+		if err := cmd.Process.Signal(syscall.Signal(0)); err != nil {
+			return
+		}
+	}
+	t.Error("the process was not stopped")
+}
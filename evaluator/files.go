@@ -0,0 +1,412 @@
+package evaluator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// fileHandle is the file opened using open
+type fileHandle struct {
+	file   *os.File
+	reader *bufio.Reader
+	closed bool
+}
+
+func (h *fileHandle) String() string {
+	return fmt.Sprintf("<file %s>", h.file.Name())
+}
+
+func (h *fileHandle) Close() error {
+	if h.closed {
+		return fmt.Errorf("file %s is already closed", h.file.Name())
+	}
+	h.closed = true
+	return h.file.Close()
+}
+
+// ReadLine returns the line without the trailing newline,
+// the second value is false at the end of the file
+func (h *fileHandle) ReadLine() (string, bool, error) {
+	if h.closed {
+		return "", false, fmt.Errorf("file %s is closed", h.file.Name())
+	}
+	line, err := h.reader.ReadString('\n')
+	if err == io.EOF {
+		return line, line != "", nil
+	}
+	line = strings.TrimSuffix(line, "\n")
+	line = strings.TrimSuffix(line, "\r")
+	return line, true, err
+}
+
+// stdin is shared by all the evaluators, so that the lines are not lost in the buffers
+var stdin = &fileHandle{file: os.Stdin, reader: bufio.NewReader(os.Stdin)}
+
+// Stdin returns the buffered standard input used by the evaluators,
+// it should be used by all the readers of the standard input
+func Stdin() io.Reader {
+	return stdin.reader
+}
+
+// ReadLine reads the line from the standard input,
+// the second value is false at the end of the input
+func ReadLine() (string, bool, error) {
+	return stdin.ReadLine()
+}
+
+func getFileHandle(obj Any) (*fileHandle, error) {
+	h, ok := obj.(*fileHandle)
+	if !ok {
+		return nil, fmt.Errorf("%v (%T) is not a file", obj, obj)
+	}
+	return h, nil
+}
+
+func fileFlags(mode Any) (int, error) {
+	switch mode {
+	case Symbol(":read"):
+		return os.O_RDONLY, nil
+	case Symbol(":write"):
+		return os.O_WRONLY | os.O_CREATE | os.O_TRUNC, nil
+	case Symbol(":append"):
+		return os.O_WRONLY | os.O_CREATE | os.O_APPEND, nil
+	default:
+		return 0, fmt.Errorf("invalid file mode %v, expected :read, :write, or :append", mode)
+	}
+}
+
+func openFn(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	var mode Any = Symbol(":read")
+	if len(objs) == 2 {
+		mode = objs[1]
+	}
+	flags, err := fileFlags(mode)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(name, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileHandle{file: file, reader: bufio.NewReader(file)}, nil
+}
+
+func closeFn(obj Any) (Any, error) {
+	if g, ok := obj.(*generator); ok {
+		g.close()
+		return nil, nil
+	}
+	h, err := getFileHandle(obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, h.Close()
+}
+
+// readLineFn reads the line from the file, or from the standard input
+// when called without arguments
+func readLineFn(objs []Any) (Any, error) {
+	if len(objs) > 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	h := stdin
+	if len(objs) == 1 {
+		var err error
+		if h, err = getFileHandle(objs[0]); err != nil {
+			return nil, err
+		}
+	}
+	line, ok, err := h.ReadLine()
+	if !ok || err != nil {
+		return nil, err
+	}
+	return newText(line), nil
+}
+
+// readLinesFn returns a lazy iterator over the lines of the file handle,
+// the file at the path (that is closed when all the lines were read),
+// or the standard input when called without arguments
+func readLinesFn(objs []Any) (Any, error) {
+	if len(objs) > 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+
+	h := stdin
+	closeAtEnd := false
+	if len(objs) == 1 {
+		switch obj := objs[0].(type) {
+		case String:
+			name, err := getText(obj)
+			if err != nil {
+				return nil, err
+			}
+			file, err := os.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			h = &fileHandle{file: file, reader: bufio.NewReader(file)}
+			closeAtEnd = true
+		default:
+			var err error
+			if h, err = getFileHandle(obj); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return linesIterator(h, closeAtEnd), nil
+}
+
+func linesIterator(h *fileHandle, closeAtEnd bool) iterator {
+	done := false
+	return &lazySeq{func() (Any, bool, error) {
+		if done {
+			return nil, false, nil
+		}
+		line, ok, err := h.ReadLine()
+		if !ok || err != nil {
+			done = true
+			if closeAtEnd && !h.closed {
+				h.Close()
+			}
+			return nil, false, err
+		}
+		return newText(line), true, nil
+	}}
+}
+
+func writeFn(objs []Any) (Any, error) {
+	if len(objs) < 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	h, err := getFileHandle(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	if h.closed {
+		return nil, fmt.Errorf("file %s is closed", h.file.Name())
+	}
+	str, err := toText(objs[1:], "")
+	if err != nil {
+		return nil, err
+	}
+	_, err = h.file.WriteString(str)
+	return nil, err
+}
+
+// withOpenFn binds the files like let and closes them
+// after evaluating the body, even if it failed
+func withOpenFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) < 2 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	bindings, ok := args[0].(List)
+	if !ok || len(bindings)%2 != 0 {
+		return nil, fmt.Errorf("invalid variable bindings %v", args[0])
+	}
+
+	var (
+		handles []*fileHandle
+		result  Any
+		err     error
+	)
+
+	localEnv := environment.NewEnv(env)
+	for i := 0; i < len(bindings) && err == nil; i += 2 {
+		name, ok := bindings[i].(Symbol)
+		if !ok {
+			err = &ErrWrongType{bindings[i]}
+			break
+		}
+		var obj Any
+		obj, err = eval(bindings[i+1], localEnv)
+		if err != nil {
+			break
+		}
+		var h *fileHandle
+		h, err = getFileHandle(obj)
+		if err != nil {
+			break
+		}
+		handles = append(handles, h)
+		localEnv.Set(name, h)
+	}
+
+	if err == nil {
+		var objs []Any
+		objs, err = evalAll(args[1:], localEnv)
+		result = last(objs)
+	}
+
+	for i := len(handles) - 1; i >= 0; i-- {
+		if handles[i].closed {
+			continue
+		}
+		if closeErr := handles[i].Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return result, err
+}
+
+// spitFn writes the content to the file, overwriting it,
+// or appending to it when called with the :append true option
+func spitFn(objs []Any) (Any, error) {
+	if len(objs) < 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	opts, err := getOptions(objs[2:], ":append")
+	if err != nil {
+		return nil, err
+	}
+
+	mode := Symbol(":write")
+	if isTrue(opts[":append"]) {
+		mode = Symbol(":append")
+	}
+	flags, _ := fileFlags(mode)
+
+	str, err := toText(objs[1:2], "")
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(name, flags, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.WriteString(str); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return nil, file.Close()
+}
+
+// slurpFn reads the whole file, or its lines when called with the :lines true option
+func slurpFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	opts, err := getOptions(objs[1:], ":lines")
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if !isTrue(opts[":lines"]) {
+		return newText(string(content)), nil
+	}
+
+	lines := List{}
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		lines = append(lines, newText(scanner.Text()))
+	}
+	return lines, scanner.Err()
+}
+
+func fileExistsFn(obj Any) (Any, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return Bool(false), nil
+	}
+	return Bool(err == nil), err
+}
+
+func deleteFileFn(obj Any) (Any, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.Remove(name)
+}
+
+func listDirFn(obj Any) (Any, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	out := List{}
+	for _, entry := range entries {
+		out = append(out, newText(entry.Name()))
+	}
+	return out, nil
+}
+
+// makeDirFn creates the directory, together with the missing parents
+func makeDirFn(obj Any) (Any, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.MkdirAll(name, 0755)
+}
+
+func pathJoinFn(objs []Any) (Any, error) {
+	parts, err := getTexts(objs)
+	if err != nil {
+		return nil, err
+	}
+	return newText(filepath.Join(parts...)), nil
+}
+
+func fileSizeFn(obj Any) (Any, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	return Int(info.Size()), nil
+}
+
+func globFn(obj Any) (Any, error) {
+	pattern, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	out := List{}
+	for _, m := range matches {
+		out = append(out, newText(m))
+	}
+	return out, nil
+}
@@ -0,0 +1,179 @@
+package evaluator
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	// the paths are substituted in the code as $dir
+	withDir := func(code string) string {
+		return strings.ReplaceAll(code, "$dir", filepath.ToSlash(dir))
+	}
+
+	var testCases = []evalTestCase{
+		{withDir(`(spit "$dir/a.txt" "hello") (slurp "$dir/a.txt")`), String("hello")},
+		{withDir(`(spit "$dir/a.txt" "hello") (spit "$dir/a.txt" "bye") (slurp "$dir/a.txt")`), String("bye")},
+		{withDir(`(spit "$dir/a.txt" 1) (spit "$dir/a.txt" #\x :append true) (slurp "$dir/a.txt")`), String("1x")},
+		{withDir(`(spit "$dir/b.txt" "x") (write-to-file "$dir/b.txt" "y") (slurp "$dir/b.txt" :lines true)`), List{String("xy")}},
+		{withDir(`(write-to-file "$dir/new.txt" "y") (slurp "$dir/new.txt")`), String(`y\n`)},
+		{withDir(`(spit "$dir/empty.txt" "") (slurp "$dir/empty.txt" :lines true)`), List{}},
+		{withDir(`(file-exists? "$dir/a.txt")`), Bool(true)},
+		{withDir(`(file-exists? "$dir/missing.txt")`), Bool(false)},
+		{withDir(`(file-size "$dir/a.txt")`), Int(2)},
+		{withDir(`(begin (delete-file "$dir/new.txt") (file-exists? "$dir/new.txt"))`), Bool(false)},
+		{withDir(`(make-dir "$dir/x/y") (spit (path-join "$dir" "x" "y" "z.csv") "") (list-dir "$dir/x/y")`), List{String("z.csv")}},
+		{withDir(`(map (fn (p) (list-dir p)) (glob "$dir/x/*"))`), List{List{String("z.csv")}}},
+		{`(path-join "a" "b/" "../c")`, String(filepath.Join("a", "c"))},
+		// the file contents are escaped like the string literals
+		{withDir(`(spit "$dir/q.txt" "say \"hi\"\\n\nbye") (upper (slurp "$dir/q.txt"))`), String(`SAY \"HI\"\\N\nBYE`)},
+		{withDir(`(slurp "$dir/q.txt" :lines true)`), List{String(`say \"hi\"\\n`), String("bye")}},
+		{withDir(`(with-open (f (open "$dir/q.txt")) (split (read-line f) "\""))`), List{String("say "), String("hi"), String(`\\n`)}},
+		{withDir(`(collect (map upper (read-lines "$dir/q.txt")))`), List{String(`SAY \"HI\"\\N`), String("BYE")}},
+		{withDir(`(spit "$dir/q.txt" "tab\there") (file-size "$dir/q.txt")`), Int(8)},
+		{withDir(`
+			(with-open (f (open "$dir/lines.txt" :write))
+				(write f "first" #\newline)
+				(write f 2 #\return #\newline "last"))
+			(with-open (f (open "$dir/lines.txt"))
+				(list (read-line f) (read-line f) (read-line f) (read-line f)))`),
+			List{String("first"), String("2"), String("last"), nil},
+		},
+		{withDir(`
+			(def f (open "$dir/lines.txt" :append))
+			(write f #\newline "appended")
+			(close f)
+			(with-open (f (open "$dir/lines.txt") g (open "$dir/a.txt"))
+				(read-line f) (read-line f) (read-line f)
+				(list (read-line f) (read-line g)))`),
+			List{String("appended"), String("1x")},
+		},
+		{withDir(`
+			(def handle nil)
+			(let/ec k
+				(with-open (f (open "$dir/a.txt"))
+					(set! handle f)
+					(k nil)))
+			(write handle "x")`),
+			nil,
+		},
+	}
+
+	// the last test case is expected to fail, because the file was closed
+	runTests(testCases[:len(testCases)-1], t)
+
+	e := NewEvaluator()
+	_, err := e.EvalString(testCases[len(testCases)-1].input)
+	if err == nil || !strings.Contains(err.Error(), "is closed") {
+		t.Errorf("expected the file to be closed, got: %v", err)
+	}
+}
+
+func TestWithOpenClosesOnError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.txt")
+
+	e := NewEvaluator()
+	e.env.Set("path", String(path))
+	_, err := e.EvalString(`
+		(def handle nil)
+		(with-open (f (open path :write))
+			(set! handle f)
+			(write f "ok")
+			(error "boom"))`)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	h, err := e.env.Get("handle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.(*fileHandle).closed {
+		t.Error("file was not closed")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil || string(content) != "ok" {
+		t.Errorf("unexpected content %q, error: %v", content, err)
+	}
+}
+
+func TestFilesErrors(t *testing.T) {
+	dir := t.TempDir()
+	var testCases = []string{
+		`(slurp "/this/file/does/not/exist.txt")`,
+		`(open "/this/file/does/not/exist.txt")`,
+		`(open "file.txt" :unknown)`,
+		`(read-line "file.txt")`,
+		`(close 42)`,
+		`(spit "file.txt")`,
+		`(spit "file.txt" "x" :mode 1)`,
+		`(with-open (f 42) f)`,
+		`(with-open (f) f)`,
+		`(delete-file "/this/file/does/not/exist.txt")`,
+		`(file-size "/this/file/does/not/exist.txt")`,
+		`(list-dir "/this/dir/does/not/exist")`,
+		`(glob "[")`,
+		`(path-join "a" 1)`,
+		`(let (f (open "` + filepath.ToSlash(dir) + `/x.txt" :write)) (close f) (close f))`,
+	}
+
+	runErrorTests(t, testCases)
+}
+
+func TestStdin(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stdin.txt")
+	if err := os.WriteFile(path, []byte("first\nsecond\r\nthird\nfourth"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	orig := stdin
+	stdin = &fileHandle{file: file, reader: bufio.NewReader(file)}
+	defer func() { stdin = orig }()
+
+	e := NewEvaluator()
+	result, err := e.EvalString(`
+		(list
+			(read-line)
+			(take 1 (read-lines))
+			(collect (stdin-seq))
+			(read-line)
+			(collect (stdin-seq)))`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := List{
+		String("first"),
+		List{String("second")},
+		List{String("third"), String("fourth")},
+		nil,
+		List{},
+	}
+	if !isEqual(last(result), expected) {
+		t.Errorf("expected %v, got %v", expected, last(result))
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lines.txt")
+	if err := os.WriteFile(path, []byte("a\nb\n\nc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var testCases = []evalTestCase{
+		{`(collect (read-lines "` + filepath.ToSlash(path) + `"))`, List{String("a"), String("b"), String(""), String("c")}},
+		{`(collect (filter (fn (x) (not (empty? (chars x)))) (read-lines "` + filepath.ToSlash(path) + `")))`, List{String("a"), String("b"), String("c")}},
+		{`(with-open (f (open "` + filepath.ToSlash(path) + `")) (read-line f) (collect (read-lines f)))`, List{String("b"), String(""), String("c")}},
+	}
+
+	runTests(testCases, t)
+}
@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// formatArg wraps the gol values so that the %s verb uses the raw
+// strings and characters, while %v uses their gol representation,
+// other verbs (e.g. %c, %x) are applied to the underlying value
+type formatArg struct {
+	raw   string
+	repr  string
+	value interface{}
+}
+
+func (a formatArg) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		fmt.Fprintf(f, directive(f, verb), a.repr)
+	case 's':
+		fmt.Fprintf(f, directive(f, verb), a.raw)
+	case 'q':
+		fmt.Fprintf(f, directive(f, 's'), strconv.Quote(a.raw))
+	default:
+		fmt.Fprintf(f, directive(f, verb), a.value)
+	}
+}
+
+// directive recreates the formatting directive, e.g. %-10s
+func directive(f fmt.State, verb rune) string {
+	d := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			d += string(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		d += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		d += "." + strconv.Itoa(p)
+	}
+	return d + string(verb)
+}
+
+func formatFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	str, ok := objs[0].(String)
+	if !ok {
+		return nil, &ErrWrongType{objs[0]}
+	}
+	// escape sequences like \n or \t are interpreted in the format string
+	format, err := str.Unquote()
+	if err != nil {
+		return nil, err
+	}
+
+	var args []interface{}
+	for _, obj := range objs[1:] {
+		switch obj := obj.(type) {
+		case String:
+			text, err := getText(obj)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, formatArg{text, obj.String(), text})
+		case Char:
+			args = append(args, formatArg{string(obj), obj.String(), rune(obj)})
+		case nil:
+			args = append(args, formatArg{"nil", "nil", nil})
+		default:
+			args = append(args, obj)
+		}
+	}
+	return newText(fmt.Sprintf(string(format), args...)), nil
+}
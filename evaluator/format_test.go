@@ -0,0 +1,49 @@
+package evaluator
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(format "%d items" 42)`, String("42 items")},
+		{`(format "%5d|%-5d|%05d" 42 42 42)`, String("   42|42   |00042")},
+		{`(format "%.2f" 3.14159)`, String("3.14")},
+		{`(format "%8.3f" 2.5)`, String("   2.500")},
+		{`(format "%x %X %o %b" 255 255 8 5)`, String("ff FF 10 101")},
+		{`(format "%s" "hello")`, String("hello")},
+		{`(format "%v" "hello")`, String(`\"hello\"`)},
+		{`(format "%q" "hello")`, String(`\"hello\"`)},
+		{`(format "[%-6s]" "ab")`, String("[ab    ]")},
+		{`(format "[%6s]" "ab")`, String("[    ab]")},
+		{`(format "%s %v %c" #\a #\a #\a)`, String(`a #\\a a`)},
+		{`(format "%x %d" "hi" #\a)`, String("6869 97")},
+		{`(format "%v" '(1 "a" b))`, String(`(1 \"a\" b)`)},
+		{`(format "%s" '(1 "a" b))`, String(`(1 \"a\" b)`)},
+		{`(format "%v %v" true nil)`, String("true nil")},
+		{`(format "%v" 1/3)`, String("1/3")},
+		{`(format "100%%")`, String("100%")},
+		{`(format "a\tb")`, String(`a\tb`)},
+		// the result is escaped like the string literals
+		{`(upper (format "a\"b"))`, String(`A\"B`)},
+		{`(format "%s|%v" "a\nb" "a\nb")`, String(`a\nb|\"a\\nb\"`)},
+		{`(string-length (format "%s" "a\nb"))`, Int(3)},
+		{`(format "%q" "a\nb")`, String(`\"a\\nb\"`)},
+		{`(let (name "World") $"Hello ${name}!")`, String("Hello World!")},
+		{`(let (x 2 y 3) $"${x} + ${y} = ${(+ x y)}")`, String("2 + 3 = 5")},
+		{`$"quoted: ${(upper \"abc\")}"`, String("quoted: ABC")},
+		{`$"plain"`, String("plain")},
+		{`(printf "")`, nil},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestFormatErrors(t *testing.T) {
+	var testCases = []string{
+		`(format)`,
+		`(format 42)`,
+		`(printf 1 2)`,
+		`$"${undefined-symbol}"`,
+	}
+
+	runErrorTests(t, testCases)
+}
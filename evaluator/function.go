@@ -29,6 +29,17 @@ func (f *singleArgFunction) Eval(args []Any, env *environment.Env) (Any, error)
 	return f.fn(obj)
 }
 
+type noArgFunction struct {
+	fn func() (Any, error)
+}
+
+func (f *noArgFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 0 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	return f.fn()
+}
+
 type multiArgFunction struct {
 	fn func([]Any) (Any, error)
 }
@@ -40,3 +51,22 @@ func (f *multiArgFunction) Eval(args []Any, env *environment.Env) (Any, error) {
 	}
 	return f.fn(objs)
 }
+
+type charFunction struct {
+	fn func(rune) Any
+}
+
+func (f *charFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	obj, err := eval(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := obj.(Char)
+	if !ok {
+		return nil, &ErrWrongType{obj}
+	}
+	return f.fn(rune(c)), nil
+}
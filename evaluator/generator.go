@@ -0,0 +1,269 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// iterator is a lazily evaluated sequence of values,
+// Next returns false when there are no more values
+type iterator interface {
+	Next() (Any, bool, error)
+}
+
+var errGeneratorClosed = errors.New("generator was closed")
+
+type step struct {
+	val Any
+	ok  bool
+	err error
+}
+
+// generator runs the function in a separate goroutine,
+// that is paused after each call to yield until the next value is requested
+type generator struct {
+	*generatorState
+}
+
+// generatorState is shared with the goroutine, so that the goroutine
+// does not keep the generator itself alive and it can be garbage collected
+type generatorState struct {
+	fn       function
+	env      *environment.Env
+	started  bool
+	finished bool
+	err      error
+	out      chan step
+	resume   chan struct{}
+	done     chan struct{}
+	once     sync.Once
+}
+
+func newGenerator(fn *lambda, env *environment.Env) (*generator, error) {
+	if len(fn.args) != 0 {
+		return nil, errors.New("generator function cannot take any arguments")
+	}
+
+	state := &generatorState{
+		env:    env,
+		out:    make(chan step),
+		resume: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	// yield is bound in the closure of the generator function
+	genEnv := environment.NewEnv(fn.env)
+	genEnv.Set("yield", &singleArgFunction{state.yield})
+	state.fn = &lambda{genEnv, fn.args, fn.expr}
+
+	g := &generator{state}
+	// abandoned generators stop their goroutines, but the paused goroutine
+	// keeps the closure of the function alive, so a generator that can be
+	// reached from its own closure is never collected and needs to be closed
+	runtime.SetFinalizer(g, func(g *generator) { g.close() })
+	return g, nil
+}
+
+func (g *generatorState) Next() (Any, bool, error) {
+	if g.finished {
+		return nil, false, g.err
+	}
+	select {
+	case <-g.done:
+		// closed generator has no more values
+		g.finished = true
+		return nil, false, nil
+	default:
+	}
+
+	if g.started {
+		g.resume <- struct{}{}
+	} else {
+		g.started = true
+		go g.run()
+	}
+
+	s := <-g.out
+	if !s.ok {
+		g.finished = true
+		g.err = s.err
+	}
+	return s.val, s.ok, s.err
+}
+
+func (g *generatorState) run() {
+	_, err := g.fn.Eval(nil, g.env)
+	if errors.Is(err, errGeneratorClosed) {
+		return
+	}
+	select {
+	case g.out <- step{nil, false, err}:
+	case <-g.done:
+	}
+}
+
+func (g *generatorState) yield(obj Any) (Any, error) {
+	select {
+	case g.out <- step{obj, true, nil}:
+	case <-g.done:
+		return nil, errGeneratorClosed
+	}
+
+	select {
+	case <-g.resume:
+		return nil, nil
+	case <-g.done:
+		return nil, errGeneratorClosed
+	}
+}
+
+func (g *generatorState) close() {
+	g.once.Do(func() { close(g.done) })
+}
+
+func (g *generator) String() string {
+	return "<generator>"
+}
+
+// lazySeq is an iterator calling a function for each value
+type lazySeq struct {
+	next func() (Any, bool, error)
+}
+
+func (s *lazySeq) Next() (Any, bool, error) {
+	return s.next()
+}
+
+func (s *lazySeq) String() string {
+	return "<iterator>"
+}
+
+func listIterator(l List) iterator {
+	i := 0
+	return &lazySeq{func() (Any, bool, error) {
+		if i >= len(l) {
+			return nil, false, nil
+		}
+		i++
+		return l[i-1], true, nil
+	}}
+}
+
+func mapIterator(fn function, it iterator, env *environment.Env) iterator {
+	return &lazySeq{func() (Any, bool, error) {
+		val, ok, err := it.Next()
+		if !ok || err != nil {
+			return nil, false, err
+		}
+		res, err := callFunction(fn, []Any{val}, env)
+		return res, err == nil, err
+	}}
+}
+
+func filterIterator(fn function, it iterator, env *environment.Env) iterator {
+	return &lazySeq{func() (Any, bool, error) {
+		for {
+			val, ok, err := it.Next()
+			if !ok || err != nil {
+				return nil, false, err
+			}
+			keep, err := callFunction(fn, []Any{val}, env)
+			if err != nil {
+				return nil, false, err
+			}
+			if isTrue(keep) {
+				return val, true, nil
+			}
+		}
+	}}
+}
+
+func generatorFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	obj, err := eval(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := obj.(*lambda)
+	if !ok {
+		return nil, &ErrWrongType{obj}
+	}
+	return newGenerator(fn, env)
+}
+
+func nextFn(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	it, ok := objs[0].(iterator)
+	if !ok {
+		return nil, &ErrWrongType{objs[0]}
+	}
+	val, ok, err := it.Next()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if len(objs) == 2 {
+			return objs[1], nil
+		}
+		return nil, errors.New("iterator is exhausted")
+	}
+	return val, nil
+}
+
+func takeFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	n, ok := objs[0].(Int)
+	if !ok {
+		return nil, &ErrWrongType{objs[0]}
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("negative number of elements: %d", n)
+	}
+
+	switch seq := objs[1].(type) {
+	case List:
+		if n < len(seq) {
+			return seq[:n], nil
+		}
+		return seq, nil
+	case iterator:
+		out := List{}
+		for i := 0; i < n; i++ {
+			val, ok, err := seq.Next()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				break
+			}
+			out = append(out, val)
+		}
+		return out, nil
+	default:
+		return nil, &ErrWrongType{objs[1]}
+	}
+}
+
+func collect(it iterator) (List, error) {
+	out := List{}
+	for {
+		val, ok, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return out, nil
+		}
+		out = append(out, val)
+	}
+}
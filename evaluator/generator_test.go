@@ -0,0 +1,133 @@
+package evaluator
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerators(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(def g (generator (fn () (yield 1) (yield 2))))
+		  (list (next g) (next g) (next g 'done))`, List{Int(1), Int(2), Symbol("done")}},
+		{`(def (count-from n)
+			(generator (fn ()
+				(def (loop i) (yield i) (loop (int+ i 1)))
+				(loop n))))
+		  (take 5 (count-from 10))`, List{Int(10), Int(11), Int(12), Int(13), Int(14)}},
+		{`(collect (generator (fn () (yield "a") (yield "b"))))`, List{String("a"), String("b")}},
+		{`(collect (generator (fn () nil)))`, List{}},
+		{`(def (naturals)
+			(generator (fn ()
+				(def (loop i) (yield i) (loop (int+ i 1)))
+				(loop 0))))
+		  (take 3 (map (fn (x) (int* x x))
+			(filter (fn (x) (= (int% x 2) 1)) (naturals))))`, List{Int(1), Int(9), Int(25)}},
+		{`(filter (fn (x) (> x 1)) '(1 2 3))`, List{Int(2), Int(3)}},
+		{`(filter (fn (x) false) '(1 2 3))`, List{}},
+		{`(take 2 '(1 2 3))`, List{Int(1), Int(2)}},
+		{`(take 5 '(1 2 3))`, List{Int(1), Int(2), Int(3)}},
+		{`(iterator? (generator (fn () nil)))`, Bool(true)},
+		{`(iterator? (map (fn (x) x) (generator (fn () nil))))`, Bool(true)},
+		{`(iterator? '())`, Bool(false)},
+		// escaping from the generator
+		{`(let/ec k
+			(collect (generator (fn () (yield 1) (k "escaped") (yield 2)))))`, String("escaped")},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestGeneratorsLaziness(t *testing.T) {
+	e := NewEvaluator()
+
+	results, err := e.EvalString(`
+	(def counter 0)
+	(def g (generator (fn ()
+		(set! counter (int+ counter 1))
+		(yield 1)
+		(set! counter (int+ counter 1))
+		(yield 2))))
+	(def m (map (fn (x) (int* x 10)) g))
+	(def before counter)
+	(list before (next m) counter)
+	`)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	expected := List{Int(0), Int(10), Int(1)}
+	if result := last(results); !cmp.Equal(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestGeneratorsErrors(t *testing.T) {
+	var testCases = []string{
+		`(yield 1)`,
+		`(next (generator (fn () nil)))`,
+		`(generator (fn (x) x))`,
+		`(collect (generator (fn () (yield 1) (error "oops"))))`,
+		`(take -1 '(1 2))`,
+		`(take -1 (generator (fn () (yield 1))))`,
+	}
+
+	runErrorTests(t, testCases)
+}
+
+func TestAbandonedGeneratorsDoNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	e := NewEvaluator()
+	_, err := e.EvalString(`
+	(def (naturals)
+		(generator (fn ()
+			(def (loop i) (yield i) (loop (int+ i 1)))
+			(loop 0))))
+	(def (consume) (take 3 (naturals)))
+	(consume) (consume) (consume)
+	`)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("expected at most %d goroutines, got %d", before, runtime.NumGoroutine())
+}
+
+func TestClosedGeneratorsDoNotLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	// the generator is bound in the closure of its own function
+	e := NewEvaluator()
+	result, err := e.EvalString(`
+	(def (consume)
+		(let (g (generator (fn () (yield 1) (yield 2))))
+			(let (x (next g))
+				(close g)
+				(list x (next g nil)))))
+	(consume) (consume) (consume)
+	`)
+	if err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if len(result) == 0 || !cmp.Equal(last(result), List{Int(1), nil}) {
+		t.Errorf("expected (1 nil), got %v", result)
+	}
+
+	for i := 0; i < 100; i++ {
+		runtime.GC()
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("expected at most %d goroutines, got %d", before, runtime.NumGoroutine())
+}
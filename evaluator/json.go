@@ -0,0 +1,230 @@
+package evaluator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/twolodzko/gol/parser"
+)
+
+// JSON objects are converted to maps with string keys, arrays to lists,
+// integers to Int (or BigInt when they do not fit), other numbers to Float,
+// and null to nil. Strings hold the escaped text, like the string literals,
+// so they are unquoted before encoding and quoted after decoding.
+
+func parseJSON(str string) (Any, error) {
+	decoder := json.NewDecoder(strings.NewReader(str))
+	decoder.UseNumber()
+
+	var obj interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected data after the JSON value")
+	}
+	return fromJSON(obj)
+}
+
+func fromJSON(obj interface{}) (Any, error) {
+	switch obj := obj.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return Bool(obj), nil
+	case string:
+		return String(obj).Quote(), nil
+	case json.Number:
+		return parseJSONNumber(string(obj))
+	case []interface{}:
+		out := List{}
+		for _, elem := range obj {
+			val, err := fromJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, val)
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(Map, len(obj))
+		for k, v := range obj {
+			val, err := fromJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			out[String(k).Quote()] = val
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value: %v", obj)
+	}
+}
+
+func parseJSONNumber(str string) (Any, error) {
+	if !strings.ContainsAny(str, ".eE") {
+		if i, err := strconv.ParseInt(str, 10, 0); err == nil {
+			return Int(i), nil
+		}
+		if i, ok := new(big.Int).SetString(str, 10); ok {
+			return i, nil
+		}
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+func toJSON(obj Any) (interface{}, error) {
+	switch obj := obj.(type) {
+	case nil, Bool, Int, Float:
+		return obj, nil
+	case BigInt:
+		return json.Number(obj.String()), nil
+	case Rational:
+		f, _ := obj.Float64()
+		return f, nil
+	case String:
+		str, err := obj.Unquote()
+		return str.Raw(), err
+	case Char:
+		return string(obj), nil
+	case Symbol:
+		return string(obj), nil
+	case List:
+		out := []interface{}{}
+		for _, elem := range obj {
+			val, err := toJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, val)
+		}
+		return out, nil
+	case Map:
+		out := make(map[string]interface{}, len(obj))
+		for k, v := range obj {
+			key, err := jsonKey(k)
+			if err != nil {
+				return nil, err
+			}
+			val, err := toJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = val
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%v (%T) cannot be converted to JSON", obj, obj)
+	}
+}
+
+func jsonKey(obj Any) (string, error) {
+	switch obj := obj.(type) {
+	case String:
+		str, err := obj.Unquote()
+		return str.Raw(), err
+	case Char:
+		return string(obj), nil
+	case nil:
+		return "", fmt.Errorf("nil cannot be used as JSON object key")
+	default:
+		return fmt.Sprint(obj), nil
+	}
+}
+
+// stringifyJSON encodes the object, the optional pretty argument
+// is either true, for two spaces indentation, or the indentation string
+func stringifyJSON(obj Any, pretty Any) (string, error) {
+	var indent string
+	switch pretty := pretty.(type) {
+	case Bool:
+		if pretty {
+			indent = "  "
+		}
+	case String:
+		str, err := pretty.Unquote()
+		if err != nil {
+			return "", err
+		}
+		indent = str.Raw()
+	case nil:
+	default:
+		return "", &ErrWrongType{pretty}
+	}
+
+	val, err := toJSON(obj)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(val); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+func jsonParseFn(obj Any) (Any, error) {
+	str, ok := obj.(String)
+	if !ok {
+		return nil, &ErrWrongType{obj}
+	}
+	unquoted, err := str.Unquote()
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(unquoted.Raw())
+}
+
+func jsonStringifyFn(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	var pretty Any
+	if len(objs) == 2 {
+		pretty = objs[1]
+	}
+	str, err := stringifyJSON(objs[0], pretty)
+	return String(str).Quote(), err
+}
+
+func readJSONFileFn(obj Any) (Any, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	str, err := parser.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return parseJSON(str)
+}
+
+// writeJSONFileFn creates or overwrites the file
+func writeJSONFileFn(objs []Any) (Any, error) {
+	if len(objs) < 2 || len(objs) > 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	var pretty Any
+	if len(objs) == 3 {
+		pretty = objs[2]
+	}
+	str, err := stringifyJSON(objs[1], pretty)
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.WriteFile(name, []byte(str+"\n"), 0644)
+}
@@ -0,0 +1,101 @@
+package evaluator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(json-parse "42")`, Int(42)},
+		{`(json-parse "-4.0")`, Float(-4)},
+		{`(json-parse "1e3")`, Float(1000)},
+		{`(json-parse "12345678901234567890")`, bigInt("12345678901234567890")},
+		{`(json-parse "null")`, nil},
+		{`(json-parse "true")`, Bool(true)},
+		{`(json-parse " [1, 2.5, [], {}] ")`, List{Int(1), Float(2.5), List{}, Map{}}},
+		{`(json-parse "\"zażółć\"")`, String("zażółć")},
+		{`(json-parse "\"a\\nb\"")`, String(`a\nb`)},
+		{`(= (json-parse "\"a\\nb\"") "a\nb")`, Bool(true)},
+		{`(json-parse "\"tab\\tquote\\\"\"")`, String(`tab\tquote\"`)},
+		{
+			`(json-parse "{\"a\": [1, null], \"b\": {\"c\": \"d\"}}")`,
+			Map{
+				String("a"): List{Int(1), nil},
+				String("b"): Map{String("c"): String("d")},
+			},
+		},
+		{`(get (json-parse "{\"a\":1}") "a")`, Int(1)},
+		{`(json-stringify nil)`, String("null")},
+		{`(json-stringify '(1 2.5 "a" true nil))`, String(`[1,2.5,\"a\",true,null]`)},
+		{`(pretty-str (json-stringify '("a\nb")))`, String(`["a\nb"]`)},
+		{`(json-stringify '())`, String(`[]`)},
+		{`(pretty-str (json-stringify (hash-map "b" 1 'a "<x>" 3 #\c)))`, String(`{"3":"c","a":"<x>","b":1}`)},
+		{`(json-stringify 123456789012345678901234567890)`, String("123456789012345678901234567890")},
+		{`(json-stringify 1/4)`, String("0.25")},
+		{`(pretty-str (json-stringify (hash-map "a" '(1 2)) true))`, String("{\n  \"a\": [\n    1,\n    2\n  ]\n}")},
+		{`(pretty-str (json-stringify '(1) "    "))`, String("[\n    1\n]")},
+		{`(pretty-str (json-stringify '(1) "\t"))`, String("[\n\t1\n]")},
+		{`(json-stringify '(1) false)`, String("[1]")},
+		{
+			`(let (m (hash-map "a" '(1 2.5 "x\ty") "b" (hash-map "c" nil)))
+				(= m (json-parse (json-stringify m))))`,
+			Bool(true),
+		},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestJSONErrors(t *testing.T) {
+	var testCases = []string{
+		`(json-parse "")`,
+		`(json-parse "[1, 2")`,
+		`(json-parse "1 2")`,
+		`(json-parse 42)`,
+		`(json-stringify (fn (x) x))`,
+		`(json-stringify 1+2i)`,
+		`(json-stringify (hash-map nil 1))`,
+		`(json-stringify '(1) 42)`,
+		`(read-json-file "/this/file/does/not/exist.json")`,
+	}
+
+	runErrorTests(t, testCases)
+}
+
+func TestJSONFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.json")
+
+	// the file is overwritten
+	if err := os.WriteFile(path, []byte("some old content\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEvaluator()
+	code := fmt.Sprintf(`
+		(write-json-file %q (hash-map "name" "gol" "tags" '("lisp" "go")) true)
+		(read-json-file %q)`, path, path)
+	results, err := e.EvalString(code)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	result := results[len(results)-1]
+
+	expected := Map{
+		String("name"): String("gol"),
+		String("tags"): List{String("lisp"), String("go")},
+	}
+	if !isEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "{\n  \"name\": \"gol\",\n  \"tags\": [\n    \"lisp\",\n    \"go\"\n  ]\n}\n" {
+		t.Errorf("unexpected file content: %q", content)
+	}
+}
@@ -0,0 +1,40 @@
+package evaluator
+
+import "fmt"
+
+// Keywords are the symbols starting with a colon, like :header, they evaluate
+// to themselves, and are used for passing the optional arguments to the functions.
+
+// isKeyword checks if the symbol starts with a colon
+func isKeyword(obj Any) bool {
+	s, ok := obj.(Symbol)
+	return ok && len(s) > 1 && s[0] == ':'
+}
+
+// getOptions reads the :keyword value pairs, failing on unknown options
+func getOptions(objs []Any, allowed ...Symbol) (map[Symbol]Any, error) {
+	if len(objs)%2 != 0 {
+		return nil, fmt.Errorf("missing value for the option %v", objs[len(objs)-1])
+	}
+	opts := make(map[Symbol]Any)
+	for i := 0; i < len(objs); i += 2 {
+		if !isKeyword(objs[i]) {
+			return nil, fmt.Errorf("%v (%T) is not a keyword", objs[i], objs[i])
+		}
+		key := objs[i].(Symbol)
+		if !containsSymbol(allowed, key) {
+			return nil, fmt.Errorf("unknown option %v", key)
+		}
+		opts[key] = objs[i+1]
+	}
+	return opts, nil
+}
+
+func containsSymbol(symbols []Symbol, s Symbol) bool {
+	for _, x := range symbols {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestKeywords(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`:foo`, Symbol(":foo")},
+		{`(list :a :b)`, List{Symbol(":a"), Symbol(":b")}},
+		{`(= :a ':a)`, Bool(true)},
+		{`(keyword? :a)`, Bool(true)},
+		{`(keyword? 'a)`, Bool(false)},
+		{`(keyword? ":a")`, Bool(false)},
+		{`(get (hash-map :a 1) :a)`, Int(1)},
+		{`(keyword? ':)`, Bool(false)},
+		{`(def f (fn (x) x)) (f :a)`, Symbol(":a")},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestGetOptions(t *testing.T) {
+	opts, err := getOptions([]Any{Symbol(":a"), Int(1), Symbol(":b"), nil}, ":a", ":b", ":c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[Symbol]Any{":a": Int(1), ":b": nil}
+	if !cmp.Equal(opts, expected) {
+		t.Errorf("expected %v, got %v", expected, opts)
+	}
+
+	var testCases = [][]Any{
+		{Symbol(":a")},
+		{Symbol(":d"), Int(1)},
+		{Symbol("a"), Int(1)},
+		{String(":a"), Int(1)},
+	}
+	for _, input := range testCases {
+		if result, err := getOptions(input, ":a"); err == nil {
+			t.Errorf("for %v expected an error, got %v", input, result)
+		}
+	}
+}
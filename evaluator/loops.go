@@ -0,0 +1,371 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// recurPoint is bound to recur inside the loop, calling it starts
+// the next iteration of the loop with the variables rebound
+type recurPoint struct {
+	env  *environment.Env
+	args []Symbol
+	body List
+}
+
+func (r *recurPoint) Eval(args []Any, env *environment.Env) (Any, error) {
+	expr, env, err := r.PartialEval(args, env)
+	if err != nil {
+		return nil, err
+	}
+	return eval(expr, env)
+}
+
+func (r *recurPoint) PartialEval(args []Any, env *environment.Env) (Any, *environment.Env, error) {
+	if len(args) != len(r.args) {
+		return nil, env, &ErrNumArgs{len(args)}
+	}
+	objs, err := evalAll(args, env)
+	if err != nil {
+		return nil, env, err
+	}
+	localEnv := environment.NewEnv(r.env)
+	for i, val := range objs {
+		localEnv.Set(r.args[i], val)
+	}
+	return r.body, localEnv, nil
+}
+
+func (r *recurPoint) String() string {
+	return "<recur>"
+}
+
+// loopFn binds the variables like let and evaluates the body,
+// where (recur <expr>...) in the tail position starts the next iteration
+func loopFn(args []Any, env *environment.Env) (Any, *environment.Env, error) {
+	if len(args) < 2 {
+		return nil, env, &ErrNumArgs{len(args)}
+	}
+	bindings, ok := args[0].(List)
+	if !ok || len(bindings)%2 != 0 {
+		return nil, env, fmt.Errorf("invalid variable bindings %v", args[0])
+	}
+	if err := checkAll(bindingValues(bindings), false); err != nil {
+		return nil, env, err
+	}
+	if err := checkBody(args[1:], true); err != nil {
+		return nil, env, err
+	}
+
+	loopEnv := environment.NewEnv(env)
+	r := &recurPoint{env: loopEnv, body: append(List{Symbol("begin")}, args[1:]...)}
+	loopEnv.Set("recur", r)
+
+	localEnv := environment.NewEnv(loopEnv)
+	for i := 0; i < len(bindings); i += 2 {
+		name, ok := bindings[i].(Symbol)
+		if !ok {
+			return nil, env, &ErrWrongType{bindings[i]}
+		}
+		val, err := eval(bindings[i+1], localEnv)
+		if err != nil {
+			return nil, env, err
+		}
+		localEnv.Set(name, val)
+		r.args = append(r.args, name)
+	}
+	return r.body, localEnv, nil
+}
+
+// checkRecur checks if recur is used only in the tail position of the loop,
+// it does not descend into the nested loops, except for the initial values
+// of the loop variables, and the bodies of the nested functions cannot use
+// recur, since they could be called after leaving the loop. The recur symbol
+// cannot be used as a value either, e.g. (apply recur args) or (map recur xs)
+func checkRecur(expr Any, tail bool) error {
+	if expr == Symbol("recur") {
+		return fmt.Errorf("recur can be used only as the function called in the tail position")
+	}
+	l, ok := expr.(List)
+	if !ok || len(l) == 0 {
+		return nil
+	}
+	args := l.Tail()
+
+	switch l.Head() {
+	case Symbol("recur"):
+		if !tail {
+			return fmt.Errorf("recur is not in the tail position: %v", l)
+		}
+		return checkAll(args, false)
+	case Symbol("quote"), Symbol("quasiquote"):
+		return nil
+	case Symbol("fn"), Symbol("def"):
+		if len(args) < 2 {
+			return nil
+		}
+		return checkAll(args[1:], false)
+	case Symbol("defmethod"):
+		if len(args) < 4 {
+			return nil
+		}
+		return checkAll(args[3:], false)
+	case Symbol("if"):
+		if len(args) != 3 {
+			return checkAll(args, false)
+		}
+		if err := checkRecur(args[0], false); err != nil {
+			return err
+		}
+		return checkAll(args[1:], tail)
+	case Symbol("begin"):
+		return checkBody(args, tail)
+	case Symbol("cond"):
+		for _, arg := range args {
+			clause, ok := arg.(List)
+			if !ok {
+				continue
+			}
+			if err := checkBody(clause, tail); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Symbol("let"), Symbol("loop"):
+		if len(args) == 0 {
+			return nil
+		}
+		var bindings Any
+		switch args[0].(type) {
+		case Symbol:
+			// named let, the body is a function
+			if len(args) < 2 {
+				return nil
+			}
+			bindings = args[1]
+			args = args[2:]
+			tail = false
+		default:
+			bindings = args[0]
+			args = args[1:]
+		}
+		if b, ok := bindings.(List); ok {
+			if err := checkAll(bindingValues(b), false); err != nil {
+				return err
+			}
+		}
+		if l.Head() == Symbol("loop") {
+			return nil
+		}
+		return checkBody(args, tail)
+	case Symbol("match"):
+		if len(args) == 0 {
+			return nil
+		}
+		if err := checkRecur(args[0], false); err != nil {
+			return err
+		}
+		for _, arg := range args[1:] {
+			clause, ok := arg.(List)
+			if !ok || len(clause) == 0 {
+				continue
+			}
+			body := clause[1:]
+			if len(body) > 1 && body[0] == Symbol(":when") {
+				if err := checkRecur(body[1], false); err != nil {
+					return err
+				}
+				body = body[2:]
+			}
+			if err := checkBody(body, tail); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return checkAll(l, false)
+	}
+}
+
+// bindingValues returns the values from the (<name> <expr>...) bindings
+func bindingValues(bindings List) []Any {
+	var values []Any
+	for i := 1; i < len(bindings); i += 2 {
+		values = append(values, bindings[i])
+	}
+	return values
+}
+
+// checkBody checks the body where only the last expression is in the tail position
+func checkBody(body []Any, tail bool) error {
+	if len(body) == 0 {
+		return nil
+	}
+	if err := checkAll(exceptLast(body), false); err != nil {
+		return err
+	}
+	return checkRecur(last(body), tail)
+}
+
+func checkAll(exprs []Any, tail bool) error {
+	for _, expr := range exprs {
+		if err := checkRecur(expr, tail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namedLet evaluates (let <name> (<name> <expr>...) <expr>...) by defining the function
+// with the name, that takes the variables as arguments, and calling it with their values
+func namedLet(args []Any, env *environment.Env) (Any, *environment.Env, error) {
+	if len(args) < 3 {
+		return nil, env, &ErrNumArgs{len(args)}
+	}
+	name := args[0].(Symbol)
+	bindings, ok := args[1].(List)
+	if !ok || len(bindings)%2 != 0 {
+		return nil, env, fmt.Errorf("invalid variable bindings %v", args[1])
+	}
+
+	var (
+		names List
+		call  = List{nil}
+	)
+	for i := 0; i < len(bindings); i += 2 {
+		val, err := eval(bindings[i+1], env)
+		if err != nil {
+			return nil, env, err
+		}
+		names = append(names, bindings[i])
+		call = append(call, List{Symbol("quote"), val})
+	}
+
+	localEnv := environment.NewEnv(env)
+	fn, err := newLambda(names, args[2:], localEnv)
+	if err != nil {
+		return nil, env, err
+	}
+	localEnv.Set(name, fn)
+	call[0] = fn
+	return call, localEnv, nil
+}
+
+// dotimesFn evaluates (dotimes (<name> <n>) <expr>...) for name from 0 to n-1
+func dotimesFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) < 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	binding, ok := args[0].(List)
+	if !ok || len(binding) != 2 {
+		return nil, fmt.Errorf("invalid binding %v", args[0])
+	}
+	name, ok := binding[0].(Symbol)
+	if !ok {
+		return nil, &ErrWrongType{binding[0]}
+	}
+	n, err := getInt(binding[1], env)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < n; i++ {
+		localEnv := environment.NewEnv(env)
+		localEnv.Set(name, i)
+		if _, err := evalAll(args[1:], localEnv); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// whileFn evaluates the body as long as the condition is true
+func whileFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) < 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	for {
+		cond, err := eval(args[0], env)
+		if err != nil {
+			return nil, err
+		}
+		if !isTrue(cond) {
+			return nil, nil
+		}
+		if _, err := evalAll(args[1:], env); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// forEach calls the function for each element of the list or iterator
+func forEach(seq Any, fn func(Any) error) error {
+	switch seq := seq.(type) {
+	case List:
+		for _, obj := range seq {
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+		return nil
+	case iterator:
+		for {
+			obj, ok, err := seq.Next()
+			if err != nil || !ok {
+				return err
+			}
+			if err := fn(obj); err != nil {
+				return err
+			}
+		}
+	default:
+		return &ErrWrongType{seq}
+	}
+}
+
+// forEachFn calls the function for the side effects, for each element of the list or iterator
+func forEachFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 2 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	fn, err := getFunction(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	seq, err := eval(args[1], env)
+	if err != nil {
+		return nil, err
+	}
+	err = forEach(seq, func(obj Any) error {
+		_, err := callFunction(fn.(function), []Any{obj}, env)
+		return err
+	})
+	return nil, err
+}
+
+// doseqFn evaluates (doseq (<name> <list>) <expr>...) for each element of the list or iterator
+func doseqFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) < 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	binding, ok := args[0].(List)
+	if !ok || len(binding) != 2 {
+		return nil, fmt.Errorf("invalid binding %v", args[0])
+	}
+	name, ok := binding[0].(Symbol)
+	if !ok {
+		return nil, &ErrWrongType{binding[0]}
+	}
+	seq, err := eval(binding[1], env)
+	if err != nil {
+		return nil, err
+	}
+	err = forEach(seq, func(obj Any) error {
+		localEnv := environment.NewEnv(env)
+		localEnv.Set(name, obj)
+		_, err := evalAll(args[1:], localEnv)
+		return err
+	})
+	return nil, err
+}
@@ -0,0 +1,104 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestLoops(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(loop (i 0 acc 1) (if (< i 5) (recur (+ i 1) (* acc 2)) acc))`, Int(32)},
+		{`(loop (i 0) (cond ((< i 100000) (recur (+ i 1))) (true i)))`, Int(100000)},
+		{`(loop (i 0 j (+ i 1)) (list i j))`, List{Int(0), Int(1)}},
+		{`(loop () 42)`, Int(42)},
+		{`(loop (l '(1 2 3) acc '())
+		    (match l
+		      (() acc)
+		      ((x & xs) (recur xs (conj acc (* x x))))))`, List{Int(1), Int(4), Int(9)}},
+		{`(loop (i 0)
+		    (let (j (+ i 1))
+		      (if (< j 10) (recur j) j)))`, Int(10)},
+		{`(loop (i 0) (begin (+ 1 1) (if (< i 3) (recur (+ i 1)) i)))`, Int(3)},
+		// nested loops have their own recur
+		{`(loop (i 0 acc '())
+		    (if (< i 3)
+		      (recur (+ i 1) (conj acc (loop (j 0) (if (< j i) (recur (+ j 1)) (* j 10)))))
+		      acc))`, List{Int(0), Int(10), Int(20)}},
+		// functions nested in the loop can use their own loops
+		{`(loop (i 0 acc '())
+		    (if (< i 2)
+		      (recur (+ i 1) (conj acc ((fn (n) (loop (j 0) (if (< j n) (recur (+ j 1)) j))) i)))
+		      acc))`, List{Int(0), Int(1)}},
+		// the variables are not visible outside of the loop
+		{`(def i 42) (loop (i 0) (if (< i 3) (recur (+ i 1)) i)) i`, Int(42)},
+		// named let
+		{`(let sum-to (n 5 acc 0) (if (= n 0) acc (sum-to (- n 1) (+ acc n))))`, Int(15)},
+		{`(let count (n 100000) (if (= n 0) 'done (count (- n 1))))`, Symbol("done")},
+		{`(def x 10) (let f (x 1 y x) (list x y))`, List{Int(1), Int(10)}},
+		{`(let f () 42)`, Int(42)},
+		// side-effecting iteration
+		{`(def acc '()) (dotimes (i 3) (set! acc (conj acc i))) acc`, List{Int(0), Int(1), Int(2)}},
+		{`(dotimes (i 3) i)`, nil},
+		{`(def n 0) (dotimes (i 0) (set! n 1)) n`, Int(0)},
+		{`(def i 0) (def s 0) (while (< i 5) (set! s (+ s i)) (set! i (+ i 1))) s`, Int(10)},
+		{`(while false 1)`, nil},
+		{`(def acc '()) (doseq (x '(1 2 3)) (set! acc (conj acc (* x 10)))) acc`, List{Int(10), Int(20), Int(30)}},
+		{`(def acc '()) (doseq (x (generator (fn () (yield 1) (yield 2)))) (set! acc (conj acc x))) acc`, List{Int(1), Int(2)}},
+		{`(def acc '()) (for-each (fn (x) (set! acc (conj acc (+ x 1)))) '(1 2 3)) acc`, List{Int(2), Int(3), Int(4)}},
+		{`(for-each println '())`, nil},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestLoopsErrors(t *testing.T) {
+	var testCases = []string{
+		`(loop (i 0))`,
+		`(loop i (recur 1))`,
+		`(loop (i) i)`,
+		`(loop (1 2) 1)`,
+		// recur not in the tail position
+		`(loop (i 0) (+ 1 (recur i)))`,
+		`(loop (i 0) (recur i) i)`,
+		`(loop (i 0) (if (recur i) 1 2))`,
+		`(loop (i 0) (cond ((recur i) 1)))`,
+		`(loop (i 0) (cond (true (recur i) 1)))`,
+		`(loop (i 0) (let (j (recur i)) j))`,
+		`(loop (i 0) (match i (x :when (recur x) 1)))`,
+		`(loop (i 0) (list (recur (+ i 1))))`,
+		`(loop (i 0) (and true (recur i)))`,
+		// recur used as a value
+		`(loop (i 0) (if (< i 3) (apply recur (list (+ i 1))) i))`,
+		`(loop (i 0) (map recur '(1)))`,
+		`(loop (i 0) (if (< i 3) (let (f recur) (f (+ i 1))) i))`,
+		`(loop (i 0) recur)`,
+		// recur in the initial values of the loop variables
+		`(loop (i (recur 1)) i)`,
+		`(loop (i 0 j (recur 5)) j)`,
+		`(loop (i 0) (loop (j (recur 1)) j))`,
+		// recur in the functions nested in the loop
+		`(loop (i 0) (if (< i 3) (+ 1 ((fn () (recur (+ i 1))))) i))`,
+		`(loop (i 0) (if (< i 3) ((fn () (recur (+ i 1)))) i))`,
+		`((loop (i 0) (fn () (recur (+ i 1)))))`,
+		`(loop (i 0) (def (f) (recur 1)) (f))`,
+		`(loop (i 0) (let f (n 1) (recur n)))`,
+		`(defmulti g type-of) (loop (i 0) (defmethod g :default (x) (recur x)) i)`,
+		// recur with the wrong number of arguments, or outside of the loop
+		`(loop (i 0) (if (< i 1) (recur 1 2) i))`,
+		`(recur 1)`,
+		`(let f (x) x)`,
+		`(let f (x 1 y) x)`,
+		`(let f (x 1) (f))`,
+		`(dotimes (i) i)`,
+		`(dotimes (i "a") i)`,
+		`(dotimes i i)`,
+		`(dotimes (i 3) (error "oops"))`,
+		`(while)`,
+		`(while (error "oops"))`,
+		`(doseq (x 1) x)`,
+		`(doseq x x)`,
+		`(for-each 1 '(1 2))`,
+		`(for-each println 1)`,
+	}
+
+	runErrorTests(t, testCases)
+}
@@ -0,0 +1,173 @@
+package evaluator
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Maps are immutable, assoc and dissoc return updated copies.
+
+// getKey checks if the object can be used as the key of a map
+func getKey(obj Any) (Any, error) {
+	switch obj.(type) {
+	case nil, Bool, Int, Float, Complex, String, Char, Symbol:
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("%v (%T) cannot be used as a key", obj, obj)
+	}
+}
+
+func getMap(obj Any) (Map, error) {
+	m, ok := obj.(Map)
+	if !ok {
+		return nil, fmt.Errorf("%v (%T) is not a map", obj, obj)
+	}
+	return m, nil
+}
+
+func copyMap(m Map) Map {
+	out := make(Map, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// setPairs sets the key-value pairs in the map
+func setPairs(m Map, objs []Any) error {
+	if len(objs)%2 != 0 {
+		return fmt.Errorf("missing value for the key %v", objs[len(objs)-1])
+	}
+	for i := 0; i < len(objs); i += 2 {
+		key, err := getKey(objs[i])
+		if err != nil {
+			return err
+		}
+		m[key] = objs[i+1]
+	}
+	return nil
+}
+
+func hashMapFn(objs []Any) (Any, error) {
+	m := make(Map, len(objs)/2)
+	err := setPairs(m, objs)
+	return m, err
+}
+
+func getFn(objs []Any) (Any, error) {
+	if len(objs) < 2 || len(objs) > 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	if r, ok := objs[0].(Record); ok {
+		if i, err := fieldIndex(r, objs[1]); err == nil {
+			return r.Values[i], nil
+		}
+		if len(objs) == 3 {
+			return objs[2], nil
+		}
+		return nil, nil
+	}
+	m, err := getMap(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	key, err := getKey(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	if val, ok := m[key]; ok {
+		return val, nil
+	}
+	if len(objs) == 3 {
+		return objs[2], nil
+	}
+	return nil, nil
+}
+
+func assocFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	if r, ok := objs[0].(Record); ok {
+		return assocRecord(r, objs[1:])
+	}
+	m, err := getMap(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	out := copyMap(m)
+	err = setPairs(out, objs[1:])
+	return out, err
+}
+
+func dissocFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	m, err := getMap(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	out := copyMap(m)
+	for _, obj := range objs[1:] {
+		key, err := getKey(obj)
+		if err != nil {
+			return nil, err
+		}
+		delete(out, key)
+	}
+	return out, nil
+}
+
+func containsFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	if r, ok := objs[0].(Record); ok {
+		_, err := fieldIndex(r, objs[1])
+		return Bool(err == nil), nil
+	}
+	m, err := getMap(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	key, err := getKey(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	_, ok := m[key]
+	return Bool(ok), nil
+}
+
+// sortedKeys returns the keys of the map sorted by their representation,
+// so that keys and vals are returned in a deterministic order
+func sortedKeys(m Map) List {
+	keys := List{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
+func keysFn(obj Any) (Any, error) {
+	m, err := getMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(m), nil
+}
+
+func valsFn(obj Any) (Any, error) {
+	m, err := getMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	vals := List{}
+	for _, k := range sortedKeys(m) {
+		vals = append(vals, m[k])
+	}
+	return vals, nil
+}
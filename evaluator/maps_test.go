@@ -0,0 +1,46 @@
+package evaluator
+
+import "testing"
+
+func TestMaps(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(hash-map)`, Map{}},
+		{`(hash-map "a" 1 'b 2)`, Map{String("a"): Int(1), Symbol("b"): Int(2)}},
+		{`(hash-map "a" 1 "a" 2)`, Map{String("a"): Int(2)}},
+		{`(get (hash-map "a" 1) "a")`, Int(1)},
+		{`(get (hash-map "a" 1) "b")`, nil},
+		{`(get (hash-map "a" 1) "b" 42)`, Int(42)},
+		{`(get (hash-map 1 "one") 1)`, String("one")},
+		{`(assoc (hash-map "a" 1) "b" 2 "a" 3)`, Map{String("a"): Int(3), String("b"): Int(2)}},
+		{`(let (m (hash-map "a" 1)) (assoc m "a" 2) m)`, Map{String("a"): Int(1)}},
+		{`(dissoc (hash-map "a" 1 "b" 2) "a" "c")`, Map{String("b"): Int(2)}},
+		{`(contains? (hash-map "a" nil) "a")`, Bool(true)},
+		{`(contains? (hash-map "a" 1) "b")`, Bool(false)},
+		{`(keys (hash-map "b" 2 "a" 1))`, List{String("a"), String("b")}},
+		{`(vals (hash-map "b" 2 "a" 1))`, List{Int(1), Int(2)}},
+		{`(keys (hash-map))`, List{}},
+		{`(count (hash-map "a" 1 "b" 2))`, Int(2)},
+		{`(empty? (hash-map))`, Bool(true)},
+		{`(map? (hash-map))`, Bool(true)},
+		{`(map? '())`, Bool(false)},
+		{`(= (hash-map "a" '(1 2)) (hash-map "a" '(1 2)))`, Bool(true)},
+		{`(= (hash-map "a" 1) (hash-map "a" 2))`, Bool(false)},
+		{`(= (hash-map "a" 1) (hash-map "b" 1))`, Bool(false)},
+		{`(str (hash-map "b" 2 "a" '(1 2)))`, String(`{\"a\" (1 2), \"b\" 2}`)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestMapsErrors(t *testing.T) {
+	var testCases = []string{
+		`(hash-map "a")`,
+		`(hash-map '(1 2) 3)`,
+		`(get '() 1)`,
+		`(get (hash-map) '(1))`,
+		`(assoc (hash-map) "a")`,
+		`(keys '(1 2))`,
+	}
+
+	runErrorTests(t, testCases)
+}
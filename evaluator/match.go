@@ -0,0 +1,223 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// matchFn evaluates the body of the first clause with the pattern matching
+// the value, with the variables bound by the pattern. The clauses have
+// the (<pattern> [:when <guard>] <expr>...) form. Patterns can be:
+//
+//	_              matches anything
+//	x              matches anything and binds it to x,
+//	               if x was already bound by the pattern, the values need to be equal
+//	42, "a", :key  literals match the equal values
+//	'foo           matches the quoted value
+//	(p1 p2)        matches the list with the elements matching the patterns
+//	(p1 & ps)      ps matches the rest of the list
+//	(? pred p)     matches when (pred value) is true and the value matches p
+//
+// The patterns are compiled to matchers when the clauses are evaluated
+// for the first time, and the clauses keep them in place of the patterns.
+func matchFn(args []Any, env *environment.Env) (Any, *environment.Env, error) {
+	if len(args) < 1 {
+		return nil, env, &ErrNumArgs{len(args)}
+	}
+	if err := compileClauses(args[1:]); err != nil {
+		return nil, env, err
+	}
+	value, err := eval(args[0], env)
+	if err != nil {
+		return nil, env, err
+	}
+
+	for _, arg := range args[1:] {
+		clause := arg.(List)
+
+		bindings := make(map[Symbol]Any)
+		ok, err := clause[0].(*compiledPattern).match(value, bindings, env)
+		if err != nil {
+			return nil, env, err
+		}
+		if !ok {
+			continue
+		}
+
+		localEnv := environment.NewEnv(env)
+		for name, val := range bindings {
+			localEnv.Set(name, val)
+		}
+
+		body := clause[1:]
+		if len(body) > 0 && body[0] == Symbol(":when") {
+			if len(body) < 2 {
+				return nil, env, fmt.Errorf("missing guard in the match clause: %v", clause)
+			}
+			cond, err := eval(body[1], localEnv)
+			if err != nil {
+				return nil, env, err
+			}
+			if !isTrue(cond) {
+				continue
+			}
+			body = body[2:]
+		}
+
+		_, err = evalAll(exceptLast(body), localEnv)
+		return last(body), localEnv, err
+	}
+	return nil, env, fmt.Errorf("no pattern matched %v", value)
+}
+
+// matcher checks if the value matches the pattern, adding the bound variables to bindings
+type matcher func(value Any, bindings map[Symbol]Any, env *environment.Env) (bool, error)
+
+// compiledPattern replaces the pattern in the match clause,
+// it prints and compares as the original pattern
+type compiledPattern struct {
+	pattern Any
+	match   matcher
+}
+
+func (p *compiledPattern) String() string {
+	return fmt.Sprintf("%v", p.pattern)
+}
+
+func (p *compiledPattern) Equal(other *compiledPattern) bool {
+	return isEqual(p.pattern, other.pattern)
+}
+
+// compileClauses compiles the patterns of the clauses that were not compiled yet
+func compileClauses(clauses []Any) error {
+	for _, arg := range clauses {
+		clause, ok := arg.(List)
+		if !ok || len(clause) < 1 {
+			return fmt.Errorf("invalid match clause: %v", arg)
+		}
+		if _, ok := clause[0].(*compiledPattern); ok {
+			continue
+		}
+		m, err := compilePattern(clause[0])
+		if err != nil {
+			return err
+		}
+		clause[0] = &compiledPattern{clause[0], m}
+	}
+	return nil
+}
+
+func compilePattern(pattern Any) (matcher, error) {
+	switch pattern := pattern.(type) {
+	case Symbol:
+		switch {
+		case pattern == "_":
+			return func(Any, map[Symbol]Any, *environment.Env) (bool, error) {
+				return true, nil
+			}, nil
+		case isKeyword(pattern):
+			return literalMatcher(pattern), nil
+		}
+		return func(value Any, bindings map[Symbol]Any, _ *environment.Env) (bool, error) {
+			if bound, ok := bindings[pattern]; ok {
+				return isEqual(bound, value), nil
+			}
+			bindings[pattern] = value
+			return true, nil
+		}, nil
+	case List:
+		switch pattern.Head() {
+		case Symbol("quote"):
+			if len(pattern) != 2 {
+				return nil, fmt.Errorf("invalid pattern: %v", pattern)
+			}
+			return literalMatcher(pattern[1]), nil
+		case Symbol("?"):
+			return compilePredicate(pattern)
+		}
+		return compileList(pattern)
+	default:
+		return literalMatcher(pattern), nil
+	}
+}
+
+func literalMatcher(literal Any) matcher {
+	return func(value Any, _ map[Symbol]Any, _ *environment.Env) (bool, error) {
+		return isEqual(literal, value), nil
+	}
+}
+
+func compileList(pattern List) (matcher, error) {
+	var (
+		elems []matcher
+		rest  matcher
+	)
+	for i, p := range pattern {
+		if p == Symbol("&") {
+			if i != len(pattern)-2 {
+				return nil, fmt.Errorf("& needs to be followed by a single pattern in %v", pattern)
+			}
+			m, err := compilePattern(pattern[i+1])
+			if err != nil {
+				return nil, err
+			}
+			rest = m
+			break
+		}
+		m, err := compilePattern(p)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, m)
+	}
+
+	return func(value Any, bindings map[Symbol]Any, env *environment.Env) (bool, error) {
+		l, ok := value.(List)
+		if !ok {
+			return false, nil
+		}
+		if len(l) < len(elems) || (rest == nil && len(l) != len(elems)) {
+			return false, nil
+		}
+		for i, m := range elems {
+			ok, err := m(l[i], bindings, env)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		if rest == nil {
+			return true, nil
+		}
+		tail := List{}
+		if len(l) > len(elems) {
+			tail = l[len(elems):]
+		}
+		return rest(tail, bindings, env)
+	}, nil
+}
+
+func compilePredicate(pattern List) (matcher, error) {
+	if len(pattern) != 3 {
+		return nil, fmt.Errorf("invalid pattern: %v", pattern)
+	}
+	inner, err := compilePattern(pattern[2])
+	if err != nil {
+		return nil, err
+	}
+	return func(value Any, bindings map[Symbol]Any, env *environment.Env) (bool, error) {
+		obj, err := eval(pattern[1], env)
+		if err != nil {
+			return false, err
+		}
+		pred, ok := obj.(function)
+		if !ok {
+			return false, &ErrNotCallable{obj}
+		}
+		res, err := callFunction(pred, []Any{value}, env)
+		if err != nil || !isTrue(res) {
+			return false, err
+		}
+		return inner(value, bindings, env)
+	}, nil
+}
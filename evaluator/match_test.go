@@ -0,0 +1,79 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(match 42 (_ "anything"))`, String("anything")},
+		{`(match 42 (x (+ x 1)))`, Int(43)},
+		{`(match 2 (1 "one") (2 "two") (_ "many"))`, String("two")},
+		{`(match 2.0 (2 "two") (_ "other"))`, String("two")},
+		{`(match "b" ("a" 1) ("b" 2))`, Int(2)},
+		{`(match #\b (#\a 1) (#\b 2))`, Int(2)},
+		{`(match :b (:a 1) (:b 2))`, Int(2)},
+		{`(match nil (nil "nil") (_ "other"))`, String("nil")},
+		{`(match false (true 1) (false 0))`, Int(0)},
+		{`(match 'foo ('bar 1) ('foo 2))`, Int(2)},
+		{`(match '(1 2) ('(1 2) "quoted list"))`, String("quoted list")},
+		// lists
+		{`(match '() (() "empty") (_ "other"))`, String("empty")},
+		{`(match '(1 2) ((x) "one") ((x y) (+ x y)))`, Int(3)},
+		{`(match '(1 2 3) ((x y) "two") (_ "other"))`, String("other")},
+		{`(match '(1 2 3) ((x & xs) xs))`, List{Int(2), Int(3)}},
+		{`(match '(1) ((x & xs) xs))`, List{}},
+		{`(match '() ((x & xs) xs) (_ "empty"))`, String("empty")},
+		{`(match '(1 (2 3) 4) ((a (b c) d) (list d c b a)))`, List{Int(4), Int(3), Int(2), Int(1)}},
+		{`(match '(add 1 2) (('add x y) (+ x y)) (('sub x y) (- x y)))`, Int(3)},
+		{`(match '(sub 1 2) (('add x y) (+ x y)) (('sub x y) (- x y)))`, Int(-1)},
+		{`(match '(1 2) ((_ _) "pair"))`, String("pair")},
+		{`(match "abc" ((x & xs) "list") (_ "not a list"))`, String("not a list")},
+		// repeated variables need to match the same value
+		{`(match '(1 1) ((x x) "same") (_ "different"))`, String("same")},
+		{`(match '(1 2) ((x x) "same") (_ "different"))`, String("different")},
+		// type predicates
+		{`(match "a" ((? int? x) (+ x 1)) ((? str? s) (str s "!")))`, String("a!")},
+		{`(match '(1 "a") (((? int? x) (? int? y)) "ints") (((? int? x) _) "int and something"))`, String("int and something")},
+		{`(match 5 ((? (fn (x) (> x 3)) _) "big") (_ "small"))`, String("big")},
+		// guards
+		{`(match 5 (x :when (< x 3) "small") (x :when (< x 10) "medium") (_ "large"))`, String("medium")},
+		{`(match '(3 4) ((x y) :when (= (+ x y) 7) "seven") (_ "other"))`, String("seven")},
+		// the bindings are local to the clause
+		{`(def x 1) (match 2 (x x)) x`, Int(1)},
+		{`(match 1 (_ 1 2 3))`, Int(3)},
+		{`(match 1 (_))`, nil},
+		// match is tail call optimized
+		{`(def (len l acc) (match l (() acc) ((_ & xs) (len xs (+ acc 1)))))
+		  (len (linspace 0 1 100000) 0)`, Int(100000)},
+		// the compiled patterns are reused and print as the original ones
+		{`(def (kind x) (match x ((? int? _) "int") ((_ & _) "list") (_ "other")))
+		  (map kind '(1 (2 3) "a" 4))`, List{String("int"), String("list"), String("other"), String("int")}},
+		{`(def code '(match '(1 2) ((x & xs) xs))) (eval code) (str code)`, String(`(match (quote (1 2)) ((x & xs) xs))`)},
+		{`(def code '(match '(1 2) ((x & xs) xs))) (eval code) (eval code)`, List{Int(2)}},
+		{`(def (rev l) (match l (() '()) ((x & xs) (conj (rev xs) x)))) (rev '(1 2 3))`, List{Int(3), Int(2), Int(1)}},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestMatchErrors(t *testing.T) {
+	var testCases = []string{
+		`(match)`,
+		`(match 1 (2 "two"))`,
+		`(match 1 x)`,
+		`(match 1 ())`,
+		`(match '(1 2) ((x & xs ys) 1))`,
+		`(match '(1 2) ((& xs ys) 1))`,
+		`(match 1 ((? int?) 1))`,
+		`(match 1 ((? 42 x) 1))`,
+		`(match 1 ((? unknown x) 1))`,
+		`(match 1 (x :when))`,
+		`(match 1 (x :when unknown 1))`,
+		`(match 1 (x (error "oops")))`,
+		// all the patterns are compiled before matching
+		`(match 1 (1 1) ((& xs ys) 2))`,
+	}
+
+	runErrorTests(t, testCases)
+}
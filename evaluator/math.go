@@ -2,6 +2,11 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"strconv"
+	"strings"
 
 	"github.com/twolodzko/gol/environment"
 )
@@ -11,14 +16,7 @@ func getFloat(obj Any, env *environment.Env) (Float, error) {
 	if err != nil {
 		return 0, err
 	}
-	switch obj := obj.(type) {
-	case Float:
-		return obj, nil
-	case Int:
-		return Float(obj), nil
-	default:
-		return 0, &ErrNaN{obj}
-	}
+	return floatValue(obj)
 }
 
 type singleArgFloatFunction struct {
@@ -29,7 +27,44 @@ func (f *singleArgFloatFunction) Eval(args []Any, env *environment.Env) (Any, er
 	if len(args) != 1 {
 		return nil, &ErrNumArgs{len(args)}
 	}
-	num, err := getFloat(args[0], env)
+	obj, err := eval(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	return vectorized(f.apply)(obj)
+}
+
+func (f *singleArgFloatFunction) apply(obj Any) (Any, error) {
+	num, err := floatValue(obj)
+	if err != nil {
+		return nil, err
+	}
+	return f.fn(num), nil
+}
+
+// singleArgNumericFunction uses the complex counterpart
+// of the function for complex arguments
+type singleArgNumericFunction struct {
+	fn  func(Float) Float
+	cfn func(complex128) complex128
+}
+
+func (f *singleArgNumericFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	obj, err := eval(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	return vectorized(f.apply)(obj)
+}
+
+func (f *singleArgNumericFunction) apply(obj Any) (Any, error) {
+	if c, ok := obj.(Complex); ok {
+		return Complex(f.cfn(complex128(c))), nil
+	}
+	num, err := floatValue(obj)
 	if err != nil {
 		return nil, err
 	}
@@ -109,59 +144,161 @@ func (f *multiArgIntFunction) Eval(args []Any, env *environment.Env) (Any, error
 	return res, nil
 }
 
-func gtFn(args []Any, env *environment.Env) (Any, error) {
-	if len(args) < 2 {
-		return nil, &ErrNumArgs{len(args)}
+func shiftFn(objs []Any, shift func(x Int, n uint) Int) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
 	}
-	first, err := eval(args[0], env)
-	if err != nil {
-		return nil, err
+	x, ok := objs[0].(Int)
+	if !ok {
+		return nil, fmt.Errorf("%v (%T) is not an int", objs[0], objs[0])
+	}
+	n, ok := objs[1].(Int)
+	if !ok || n < 0 {
+		return nil, fmt.Errorf("invalid shift count: %v", objs[1])
 	}
+	return shift(x, uint(n)), nil
+}
 
-	for _, obj := range args[1:] {
-		second, err := eval(obj, env)
-		if err != nil {
-			return nil, err
+// formatInt formats the integer using the 0x, 0b prefixes
+// that are also used by the integer literals
+func formatInt(obj Any, base int, prefix string) (Any, error) {
+	var str string
+	switch obj := obj.(type) {
+	case Int:
+		str = strconv.FormatInt(int64(obj), base)
+	case BigInt:
+		str = obj.Text(base)
+	default:
+		return nil, fmt.Errorf("%v (%T) is not an int", obj, obj)
+	}
+	if strings.HasPrefix(str, "-") {
+		return String("-" + prefix + str[1:]), nil
+	}
+	return String(prefix + str), nil
+}
+
+func absFn(obj Any) (Any, error) {
+	switch obj := obj.(type) {
+	case Int:
+		if obj >= 0 {
+			return obj, nil
+		}
+		return subOp.apply(Int(0), obj)
+	case BigInt:
+		return new(big.Int).Abs(obj), nil
+	case Rational:
+		return new(big.Rat).Abs(obj), nil
+	case Float:
+		return math.Abs(obj), nil
+	case Complex:
+		return cmplx.Abs(complex128(obj)), nil
+	default:
+		return nil, &ErrNaN{obj}
+	}
+}
+
+func realFn(obj Any) (Any, error) {
+	switch obj := obj.(type) {
+	case Complex:
+		return real(obj), nil
+	default:
+		if !isNumber(obj) {
+			return nil, &ErrNaN{obj}
 		}
+		return obj, nil
+	}
+}
 
-		switch first := first.(type) {
-		case Int:
-			switch second := second.(type) {
-			case Int:
-				if first <= second {
-					return Bool(false), nil
-				}
-			case Float:
-				if Float(first) <= second {
-					return Bool(false), nil
-				}
-			default:
-				return nil, &ErrWrongType{second}
-			}
-		case Float:
-			switch second := second.(type) {
-			case Float:
-				if first <= second {
-					return Bool(false), nil
-				}
-			case Int:
-				if first <= Float(second) {
-					return Bool(false), nil
-				}
-			default:
-				return nil, &ErrWrongType{second}
-			}
-		default:
-			return nil, &ErrWrongType{first}
+func imagFn(obj Any) (Any, error) {
+	switch obj := obj.(type) {
+	case Complex:
+		return imag(obj), nil
+	default:
+		if !isNumber(obj) {
+			return nil, &ErrNaN{obj}
 		}
+		return Int(0), nil
+	}
+}
 
-		first = second
+func phaseFn(obj Any) (Any, error) {
+	c, err := complexValue(obj)
+	if err != nil {
+		return nil, err
 	}
+	return cmplx.Phase(complex128(c)), nil
+}
 
-	return Bool(true), nil
+func conjugateFn(obj Any) (Any, error) {
+	switch obj := obj.(type) {
+	case Complex:
+		return Complex(cmplx.Conj(complex128(obj))), nil
+	default:
+		if !isNumber(obj) {
+			return nil, &ErrNaN{obj}
+		}
+		return obj, nil
+	}
+}
+
+func complexFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	re, err := floatValue(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	im, err := floatValue(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	return Complex(complex(re, im)), nil
+}
+
+func gtFn(args []Any, env *environment.Env) (Any, error) {
+	return compareFn(args, env, func(c int) bool { return c > 0 })
 }
 
 func ltFn(args []Any, env *environment.Env) (Any, error) {
+	return compareFn(args, env, func(c int) bool { return c < 0 })
+}
+
+// compare compares numbers, times, or durations
+func compare(x, y Any) (int, bool, error) {
+	switch first := x.(type) {
+	case Time:
+		second, ok := y.(Time)
+		if !ok {
+			return 0, false, fmt.Errorf("cannot compare %v (%T) with %v (%T)", x, x, y, y)
+		}
+		switch {
+		case first.Before(second.Time):
+			return -1, true, nil
+		case first.After(second.Time):
+			return 1, true, nil
+		default:
+			return 0, true, nil
+		}
+	case Duration:
+		second, ok := y.(Duration)
+		if !ok {
+			return 0, false, fmt.Errorf("cannot compare %v (%T) with %v (%T)", x, x, y, y)
+		}
+		switch {
+		case first < second:
+			return -1, true, nil
+		case first > second:
+			return 1, true, nil
+		default:
+			return 0, true, nil
+		}
+	default:
+		return compareNumbers(x, y)
+	}
+}
+
+func compareFn(args []Any, env *environment.Env, check func(int) bool) (Any, error) {
 	if len(args) < 2 {
 		return nil, &ErrNumArgs{len(args)}
 	}
@@ -176,35 +313,12 @@ func ltFn(args []Any, env *environment.Env) (Any, error) {
 			return nil, err
 		}
 
-		switch first := first.(type) {
-		case Int:
-			switch second := second.(type) {
-			case Int:
-				if first >= second {
-					return Bool(false), nil
-				}
-			case Float:
-				if Float(first) >= second {
-					return Bool(false), nil
-				}
-			default:
-				return nil, &ErrWrongType{second}
-			}
-		case Float:
-			switch second := second.(type) {
-			case Float:
-				if first >= second {
-					return Bool(false), nil
-				}
-			case Int:
-				if first >= Float(second) {
-					return Bool(false), nil
-				}
-			default:
-				return nil, &ErrWrongType{second}
-			}
-		default:
-			return nil, &ErrWrongType{first}
+		c, ok, err := compare(first, second)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !check(c) {
+			return Bool(false), nil
 		}
 
 		first = second
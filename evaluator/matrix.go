@@ -0,0 +1,507 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errSingularMatrix = errors.New("matrix is singular")
+
+// maxMatrixSize is the maximal number of elements of the matrix
+const maxMatrixSize = 1 << 26
+
+func newMatrix(rows, cols int) Matrix {
+	return Matrix{Rows: rows, Cols: cols, Data: make([]float64, rows*cols)}
+}
+
+func getMatrix(obj Any) (Matrix, error) {
+	m, ok := obj.(Matrix)
+	if !ok {
+		return Matrix{}, fmt.Errorf("%v (%T) is not a matrix", obj, obj)
+	}
+	return m, nil
+}
+
+// getDims returns the number of rows and columns, checking
+// if the matrix would not be too large
+func getDims(rowsObj, colsObj Any) (int, int, error) {
+	rows, ok := rowsObj.(Int)
+	if !ok || rows < 0 {
+		return 0, 0, &ErrWrongType{rowsObj}
+	}
+	cols, ok := colsObj.(Int)
+	if !ok || cols < 0 {
+		return 0, 0, &ErrWrongType{colsObj}
+	}
+	// checked by dividing, so that the product cannot overflow
+	if cols > 0 && rows > maxMatrixSize/cols {
+		return 0, 0, fmt.Errorf("%dx%d matrix is too large", rows, cols)
+	}
+	return rows, cols, nil
+}
+
+// getIndex returns the index, checking if it is in [0, size)
+func getMatrixIndex(obj Any, size int) (int, error) {
+	i, ok := obj.(Int)
+	if !ok {
+		return 0, &ErrWrongType{obj}
+	}
+	if i < 0 || i >= size {
+		return 0, fmt.Errorf("index %d out of range [0, %d)", i, size)
+	}
+	return i, nil
+}
+
+// matrixFromList creates the matrix from the list of rows
+func matrixFromList(l List) (Matrix, error) {
+	if len(l) == 0 {
+		return Matrix{}, nil
+	}
+	first, ok := l[0].(List)
+	if !ok {
+		return Matrix{}, &ErrWrongType{l[0]}
+	}
+
+	m := newMatrix(len(l), len(first))
+	for i, obj := range l {
+		row, ok := obj.(List)
+		if !ok {
+			return Matrix{}, &ErrWrongType{obj}
+		}
+		if len(row) != m.Cols {
+			return Matrix{}, fmt.Errorf("rows of different lengths (%d and %d)", m.Cols, len(row))
+		}
+		for j, x := range row {
+			num, err := floatValue(x)
+			if err != nil {
+				return Matrix{}, err
+			}
+			m.Data[i*m.Cols+j] = num
+		}
+	}
+	return m, nil
+}
+
+// matrixFn creates the matrix from the nested lists, or the matrix of zeros
+// with the dimensions, optionally filled with the values in the row-major order
+func matrixFn(objs []Any) (Any, error) {
+	switch len(objs) {
+	case 1:
+		l, ok := objs[0].(List)
+		if !ok {
+			return nil, &ErrWrongType{objs[0]}
+		}
+		return matrixFromList(l)
+	case 2, 3:
+		rows, cols, err := getDims(objs[0], objs[1])
+		if err != nil {
+			return nil, err
+		}
+		m := newMatrix(rows, cols)
+		if len(objs) == 2 {
+			return m, nil
+		}
+		nums, err := getFloats(objs[2])
+		if err != nil {
+			return nil, err
+		}
+		if len(nums) != len(m.Data) {
+			return nil, fmt.Errorf("%d values cannot fill %dx%d matrix", len(nums), rows, cols)
+		}
+		copy(m.Data, nums)
+		return m, nil
+	default:
+		return nil, &ErrNumArgs{len(objs)}
+	}
+}
+
+func identity(n int) Matrix {
+	m := newMatrix(n, n)
+	for i := 0; i < n; i++ {
+		m.Data[i*n+i] = 1
+	}
+	return m
+}
+
+func identityFn(obj Any) (Any, error) {
+	n, _, err := getDims(obj, obj)
+	if err != nil {
+		return nil, err
+	}
+	return identity(n), nil
+}
+
+func matrixToList(m Matrix) List {
+	l := make(List, m.Rows)
+	for i := range l {
+		l[i] = matrixRow(m, i)
+	}
+	return l
+}
+
+func matrixRow(m Matrix, i int) List {
+	row := make(List, m.Cols)
+	for j := range row {
+		row[j] = m.At(i, j)
+	}
+	return row
+}
+
+func matrixCol(m Matrix, j int) List {
+	col := make(List, m.Rows)
+	for i := range col {
+		col[i] = m.At(i, j)
+	}
+	return col
+}
+
+func transpose(m Matrix) Matrix {
+	t := newMatrix(m.Cols, m.Rows)
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < m.Cols; j++ {
+			t.Data[j*t.Cols+i] = m.At(i, j)
+		}
+	}
+	return t
+}
+
+func matMul(a, b Matrix) (Matrix, error) {
+	if a.Cols != b.Rows {
+		return Matrix{}, fmt.Errorf("cannot multiply %dx%d and %dx%d matrices", a.Rows, a.Cols, b.Rows, b.Cols)
+	}
+	m := newMatrix(a.Rows, b.Cols)
+	for i := 0; i < a.Rows; i++ {
+		for k := 0; k < a.Cols; k++ {
+			x := a.At(i, k)
+			for j := 0; j < b.Cols; j++ {
+				m.Data[i*m.Cols+j] += x * b.At(k, j)
+			}
+		}
+	}
+	return m, nil
+}
+
+func scale(m Matrix, x float64) Matrix {
+	s := newMatrix(m.Rows, m.Cols)
+	for i, y := range m.Data {
+		s.Data[i] = x * y
+	}
+	return s
+}
+
+// columnVector converts the list to the single-column matrix
+func columnVector(l List) (Matrix, error) {
+	nums, err := getFloats(l)
+	if err != nil {
+		return Matrix{}, err
+	}
+	return Matrix{Rows: len(nums), Cols: 1, Data: nums}, nil
+}
+
+// matMulFn multiplies the matrices, numbers, and the lists used as column vectors,
+// the result is a list when the last argument is a list
+func matMulFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+
+	var (
+		result   Any = Float(1)
+		isVector bool
+	)
+	for _, obj := range objs {
+		switch obj := obj.(type) {
+		case Matrix:
+			switch res := result.(type) {
+			case Matrix:
+				m, err := matMul(res, obj)
+				if err != nil {
+					return nil, err
+				}
+				result = m
+			case Float:
+				result = scale(obj, res)
+			}
+			isVector = false
+		case List:
+			v, err := columnVector(obj)
+			if err != nil {
+				return nil, err
+			}
+			res, ok := result.(Matrix)
+			if !ok {
+				return nil, fmt.Errorf("cannot multiply %v by the vector", result)
+			}
+			m, err := matMul(res, v)
+			if err != nil {
+				return nil, err
+			}
+			result = m
+			isVector = true
+		default:
+			x, err := floatValue(obj)
+			if err != nil {
+				return nil, err
+			}
+			switch res := result.(type) {
+			case Matrix:
+				result = scale(res, x)
+			case Float:
+				result = res * x
+			}
+		}
+	}
+
+	if m, ok := result.(Matrix); ok && isVector {
+		return matrixCol(m, 0), nil
+	}
+	return result, nil
+}
+
+// lu is the LU decomposition with partial pivoting, PA = LU,
+// where L and U are stored together in the lu matrix
+type lu struct {
+	lu    Matrix
+	pivot []int
+	sign  float64
+}
+
+func decompose(m Matrix) (*lu, error) {
+	if m.Rows != m.Cols {
+		return nil, fmt.Errorf("%dx%d matrix is not square", m.Rows, m.Cols)
+	}
+	n := m.Rows
+	a := newMatrix(n, n)
+	copy(a.Data, m.Data)
+
+	pivot := make([]int, n)
+	for i := range pivot {
+		pivot[i] = i
+	}
+	sign := 1.0
+
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a.At(i, k)) > math.Abs(a.At(p, k)) {
+				p = i
+			}
+		}
+		if a.At(p, k) == 0 {
+			return &lu{a, pivot, 0}, errSingularMatrix
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				a.Data[k*n+j], a.Data[p*n+j] = a.Data[p*n+j], a.Data[k*n+j]
+			}
+			pivot[k], pivot[p] = pivot[p], pivot[k]
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			f := a.At(i, k) / a.At(k, k)
+			a.Data[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				a.Data[i*n+j] -= f * a.At(k, j)
+			}
+		}
+	}
+	return &lu{a, pivot, sign}, nil
+}
+
+func (d *lu) det() float64 {
+	det := d.sign
+	for i := 0; i < d.lu.Rows; i++ {
+		det *= d.lu.At(i, i)
+	}
+	return det
+}
+
+// solve solves AX = B for X
+func (d *lu) solve(b Matrix) (Matrix, error) {
+	n := d.lu.Rows
+	if b.Rows != n {
+		return Matrix{}, fmt.Errorf("cannot solve %dx%d system for %dx%d matrix", n, n, b.Rows, b.Cols)
+	}
+
+	x := newMatrix(b.Rows, b.Cols)
+	for i, p := range d.pivot {
+		copy(x.Data[i*x.Cols:(i+1)*x.Cols], b.Data[p*b.Cols:(p+1)*b.Cols])
+	}
+
+	for j := 0; j < x.Cols; j++ {
+		// forward substitution with the unit lower triangular L
+		for i := 0; i < n; i++ {
+			for k := 0; k < i; k++ {
+				x.Data[i*x.Cols+j] -= d.lu.At(i, k) * x.At(k, j)
+			}
+		}
+		// back substitution with U
+		for i := n - 1; i >= 0; i-- {
+			for k := i + 1; k < n; k++ {
+				x.Data[i*x.Cols+j] -= d.lu.At(i, k) * x.At(k, j)
+			}
+			x.Data[i*x.Cols+j] /= d.lu.At(i, i)
+		}
+	}
+	return x, nil
+}
+
+func detFn(obj Any) (Any, error) {
+	m, err := getMatrix(obj)
+	if err != nil {
+		return nil, err
+	}
+	d, err := decompose(m)
+	if err == errSingularMatrix {
+		return Float(0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Float(d.det()), nil
+}
+
+func inverseFn(obj Any) (Any, error) {
+	m, err := getMatrix(obj)
+	if err != nil {
+		return nil, err
+	}
+	d, err := decompose(m)
+	if err != nil {
+		return nil, err
+	}
+	return d.solve(identity(m.Rows))
+}
+
+// solveFn solves the linear system Ax = b, where b is a matrix,
+// or a list, then the result is also a list
+func solveFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	a, err := getMatrix(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	d, err := decompose(a)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b := objs[1].(type) {
+	case Matrix:
+		return d.solve(b)
+	case List:
+		v, err := columnVector(b)
+		if err != nil {
+			return nil, err
+		}
+		x, err := d.solve(v)
+		if err != nil {
+			return nil, err
+		}
+		return matrixCol(x, 0), nil
+	default:
+		return nil, &ErrWrongType{b}
+	}
+}
+
+// matGetFn returns the element of the matrix
+func matGetFn(objs []Any) (Any, error) {
+	if len(objs) != 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	m, err := getMatrix(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	i, err := getMatrixIndex(objs[1], m.Rows)
+	if err != nil {
+		return nil, err
+	}
+	j, err := getMatrixIndex(objs[2], m.Cols)
+	if err != nil {
+		return nil, err
+	}
+	return Float(m.At(i, j)), nil
+}
+
+func matRowFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	m, err := getMatrix(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	i, err := getMatrixIndex(objs[1], m.Rows)
+	if err != nil {
+		return nil, err
+	}
+	return matrixRow(m, i), nil
+}
+
+func matColFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	m, err := getMatrix(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	j, err := getMatrixIndex(objs[1], m.Cols)
+	if err != nil {
+		return nil, err
+	}
+	return matrixCol(m, j), nil
+}
+
+// getRange returns the [start, end) range of the indexes, given as
+// the (start end) list, or nil for all the indexes
+func getRange(obj Any, size int) (int, int, error) {
+	if obj == nil {
+		return 0, size, nil
+	}
+	l, ok := obj.(List)
+	if !ok || len(l) != 2 {
+		return 0, 0, fmt.Errorf("%v is not a valid (start end) range", obj)
+	}
+	start, ok := l[0].(Int)
+	if !ok {
+		return 0, 0, &ErrWrongType{l[0]}
+	}
+	end, ok := l[1].(Int)
+	if !ok {
+		return 0, 0, &ErrWrongType{l[1]}
+	}
+	if start < 0 || end > size || start > end {
+		return 0, 0, fmt.Errorf("range (%d %d) out of bounds [0, %d]", start, end, size)
+	}
+	return start, end, nil
+}
+
+// submatrixFn slices the matrix using the row and column ranges
+func submatrixFn(objs []Any) (Any, error) {
+	if len(objs) != 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	m, err := getMatrix(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	r0, r1, err := getRange(objs[1], m.Rows)
+	if err != nil {
+		return nil, err
+	}
+	c0, c1, err := getRange(objs[2], m.Cols)
+	if err != nil {
+		return nil, err
+	}
+
+	s := newMatrix(r1-r0, c1-c0)
+	for i := r0; i < r1; i++ {
+		copy(s.Data[(i-r0)*s.Cols:(i-r0+1)*s.Cols], m.Data[i*m.Cols+c0:i*m.Cols+c1])
+	}
+	return s, nil
+}
@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatrix(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(matrix '((1 2) (3 4)))`, Matrix{Rows: 2, Cols: 2, Data: []float64{1, 2, 3, 4}}},
+		{`(matrix '())`, Matrix{}},
+		{`(matrix 2 3)`, Matrix{Rows: 2, Cols: 3, Data: []float64{0, 0, 0, 0, 0, 0}}},
+		{`(matrix 2 2 '(1 2 3 4))`, Matrix{Rows: 2, Cols: 2, Data: []float64{1, 2, 3, 4}}},
+		{`(matrix? (identity 2))`, Bool(true)},
+		{`(matrix? '((1)))`, Bool(false)},
+		{`(identity 2)`, Matrix{Rows: 2, Cols: 2, Data: []float64{1, 0, 0, 1}}},
+		{`(dims (matrix 2 3))`, List{Int(2), Int(3)}},
+		{`(matrix->list (matrix '((1 2) (3 4))))`, List{List{Float(1), Float(2)}, List{Float(3), Float(4)}}},
+		{`(= (matrix '((1 2))) (matrix 1 2 '(1 2)))`, Bool(true)},
+		{`(= (matrix '((1 2))) (matrix 2 1 '(1 2)))`, Bool(false)},
+		{`(str (matrix '((1 2) (30 -4.5))))`, String(`#matrix(( 1    2)\n        (30 -4.5))`)},
+		// element access and slicing
+		{`(mat-get (matrix '((1 2) (3 4))) 1 0)`, Float(3)},
+		{`(mat-row (matrix '((1 2) (3 4))) 1)`, List{Float(3), Float(4)}},
+		{`(mat-col (matrix '((1 2) (3 4))) 1)`, List{Float(2), Float(4)}},
+		{`(submatrix (matrix '((1 2 3) (4 5 6) (7 8 9))) '(1 3) '(0 2))`, Matrix{Rows: 2, Cols: 2, Data: []float64{4, 5, 7, 8}}},
+		{`(submatrix (matrix '((1 2 3) (4 5 6))) nil '(2 3))`, Matrix{Rows: 2, Cols: 1, Data: []float64{3, 6}}},
+		{`(submatrix (matrix '((1 2) (3 4))) '(1 1) nil)`, Matrix{Rows: 0, Cols: 2, Data: []float64{}}},
+		// linear algebra
+		{`(transpose (matrix '((1 2 3) (4 5 6))))`, Matrix{Rows: 3, Cols: 2, Data: []float64{1, 4, 2, 5, 3, 6}}},
+		{`(mat* (matrix '((1 2) (3 4))) (matrix '((5 6) (7 8))))`, Matrix{Rows: 2, Cols: 2, Data: []float64{19, 22, 43, 50}}},
+		{`(mat* (matrix '((1 2 3))) (matrix '((1) (2) (3))))`, Matrix{Rows: 1, Cols: 1, Data: []float64{14}}},
+		{`(mat* 2 (identity 2) 3)`, Matrix{Rows: 2, Cols: 2, Data: []float64{6, 0, 0, 6}}},
+		{`(mat* (matrix '((1 2) (3 4))) '(1 1))`, List{Float(3), Float(7)}},
+		{`(mat* (matrix '((1 2) (3 4))) (identity 2))`, Matrix{Rows: 2, Cols: 2, Data: []float64{1, 2, 3, 4}}},
+		{`(det (identity 3))`, Float(1)},
+		{`(det (matrix '((2 0) (0 3))))`, Float(6)},
+		{`(det (matrix '((0 1) (1 0))))`, Float(-1)},
+		{`(det (matrix '((1 2) (2 4))))`, Float(0)},
+		{`(det (matrix 0 0))`, Float(1)},
+		{`(inverse (matrix '((2 0) (0 4))))`, Matrix{Rows: 2, Cols: 2, Data: []float64{0.5, 0, 0, 0.25}}},
+		{`(inverse (matrix '((0 1) (1 0))))`, Matrix{Rows: 2, Cols: 2, Data: []float64{0, 1, 1, 0}}},
+		{`(solve (matrix '((2 0) (0 4))) '(1 1))`, List{Float(0.5), Float(0.25)}},
+		{`(solve (matrix '((0 1) (1 0))) (matrix '((1 2) (3 4))))`, Matrix{Rows: 2, Cols: 2, Data: []float64{3, 4, 1, 2}}},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestLinearAlgebra(t *testing.T) {
+	a := Matrix{Rows: 3, Cols: 3, Data: []float64{4, -2, 1, -2, 4, -2, 1, -2, 4}}
+	b := Matrix{Rows: 3, Cols: 1, Data: []float64{11, -16, 17}}
+	expected := []float64{1, -2, 3}
+
+	d, err := decompose(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if det := d.det(); math.Abs(det-36) > 1e-9 {
+		t.Errorf("expected determinant 36, got %v", det)
+	}
+
+	x, err := d.solve(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range expected {
+		if math.Abs(x.Data[i]-expected[i]) > 1e-9 {
+			t.Errorf("expected %v, got %v", expected, x.Data)
+		}
+	}
+
+	inv, err := d.solve(identity(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	prod, err := matMul(a, inv)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, v := range identity(3).Data {
+		if math.Abs(prod.Data[i]-v) > 1e-9 {
+			t.Errorf("expected identity, got %v", prod)
+			break
+		}
+	}
+}
+
+func TestMatrixErrors(t *testing.T) {
+	var testCases = []string{
+		`(matrix '((1 2) (3)))`,
+		`(matrix '((1 "a")))`,
+		`(matrix '(1 2))`,
+		`(matrix 2 2 '(1 2 3))`,
+		`(matrix -1 2)`,
+		`(matrix 1)`,
+		`(identity -1)`,
+		`(identity 100000)`,
+		`(matrix 3000000000 3000000000)`,
+		`(matrix 9223372036854775807 2)`,
+		`(matrix 1 -5)`,
+		`(dims '((1)))`,
+		`(mat-get (identity 2) 2 0)`,
+		`(mat-get (identity 2) 0 -1)`,
+		`(mat-row (identity 2) 5)`,
+		`(mat-col (identity 2) 1.0)`,
+		`(submatrix (identity 2) '(0 3) nil)`,
+		`(submatrix (identity 2) '(1 0) nil)`,
+		`(submatrix (identity 2) '(0) nil)`,
+		`(mat* (matrix 2 3) (matrix 2 3))`,
+		`(mat* '(1 2) (identity 2))`,
+		`(mat* (identity 2) '(1 2 3))`,
+		`(mat* (identity 2) "a")`,
+		`(transpose '((1 2)))`,
+		`(det (matrix 2 3))`,
+		`(inverse (matrix '((1 2) (2 4))))`,
+		`(inverse (matrix 2 3))`,
+		`(solve (matrix '((1 2) (2 4))) '(1 2))`,
+		`(solve (identity 2) '(1 2 3))`,
+		`(solve (identity 2) 1)`,
+	}
+
+	runErrorTests(t, testCases)
+}
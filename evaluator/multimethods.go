@@ -0,0 +1,185 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// multiMethod calls the method chosen by the value returned
+// by the dispatch function called with the same arguments
+type multiMethod struct {
+	name     Symbol
+	dispatch function
+	methods  []method
+}
+
+type method struct {
+	value Any
+	fn    function
+}
+
+// defaultMethod is used when no other method matches the dispatch value
+const defaultMethod = Symbol(":default")
+
+func (m *multiMethod) Eval(args []Any, env *environment.Env) (Any, error) {
+	expr, env, err := m.PartialEval(args, env)
+	if err != nil {
+		return nil, err
+	}
+	return eval(expr, env)
+}
+
+func (m *multiMethod) PartialEval(args []Any, env *environment.Env) (Any, *environment.Env, error) {
+	objs, err := evalAll(args, env)
+	if err != nil {
+		return nil, env, err
+	}
+	value, err := callFunction(m.dispatch, objs, env)
+	if err != nil {
+		return nil, env, err
+	}
+	fn, err := m.find(value)
+	if err != nil {
+		return nil, env, err
+	}
+
+	quoted := make([]Any, len(objs))
+	for i, obj := range objs {
+		quoted[i] = List{Symbol("quote"), obj}
+	}
+	if fn, ok := fn.(tailCallOptimized); ok {
+		return fn.PartialEval(quoted, env)
+	}
+	res, err := fn.Eval(quoted, env)
+	return List{Symbol("quote"), res}, env, err
+}
+
+func (m *multiMethod) find(value Any) (function, error) {
+	var fallback function
+	for _, method := range m.methods {
+		if isEqual(method.value, value) {
+			return method.fn, nil
+		}
+		if method.value == defaultMethod {
+			fallback = method.fn
+		}
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, fmt.Errorf("no method of %s for the dispatch value %v", m.name, value)
+}
+
+// add adds the method, replacing the one with the same dispatch value
+func (m *multiMethod) add(value Any, fn function) {
+	for i, method := range m.methods {
+		if isEqual(method.value, value) {
+			m.methods[i].fn = fn
+			return
+		}
+	}
+	m.methods = append(m.methods, method{value, fn})
+}
+
+func (m *multiMethod) String() string {
+	return fmt.Sprintf("<multimethod %s>", m.name)
+}
+
+func defmultiFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 2 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	name, ok := args[0].(Symbol)
+	if !ok {
+		return nil, &ErrWrongType{args[0]}
+	}
+	obj, err := eval(args[1], env)
+	if err != nil {
+		return nil, err
+	}
+	dispatch, ok := obj.(function)
+	if !ok {
+		return nil, &ErrNotCallable{obj}
+	}
+	m := &multiMethod{name: name, dispatch: dispatch}
+	env.Set(name, m)
+	return m, nil
+}
+
+func defmethodFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) < 4 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	name, ok := args[0].(Symbol)
+	if !ok {
+		return nil, &ErrWrongType{args[0]}
+	}
+	obj, err := env.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := obj.(*multiMethod)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a multimethod", name)
+	}
+	value, err := eval(args[1], env)
+	if err != nil {
+		return nil, err
+	}
+	fn, err := newLambda(args[2], args[3:], env)
+	if err != nil {
+		return nil, err
+	}
+	m.add(value, fn)
+	return m, nil
+}
+
+// typeOf returns the name of the type, or the name of the record type
+func typeOf(obj Any) (Any, error) {
+	switch obj := obj.(type) {
+	case nil:
+		return Symbol("nil"), nil
+	case Bool:
+		return Symbol("bool"), nil
+	case Int, BigInt:
+		return Symbol("int"), nil
+	case Rational:
+		return Symbol("rational"), nil
+	case Float:
+		return Symbol("float"), nil
+	case Complex:
+		return Symbol("complex"), nil
+	case String:
+		return Symbol("str"), nil
+	case Char:
+		return Symbol("char"), nil
+	case Symbol:
+		if isKeyword(obj) {
+			return Symbol("keyword"), nil
+		}
+		return Symbol("symbol"), nil
+	case Regex:
+		return Symbol("regex"), nil
+	case List:
+		return Symbol("list"), nil
+	case Map:
+		return Symbol("map"), nil
+	case Record:
+		return obj.Type.Name, nil
+	case Time:
+		return Symbol("time"), nil
+	case Duration:
+		return Symbol("duration"), nil
+	case Matrix:
+		return Symbol("matrix"), nil
+	case *fileHandle:
+		return Symbol("file"), nil
+	case iterator:
+		return Symbol("iterator"), nil
+	case function:
+		return Symbol("fn"), nil
+	default:
+		return nil, fmt.Errorf("unknown type of %v (%T)", obj, obj)
+	}
+}
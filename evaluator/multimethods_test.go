@@ -0,0 +1,91 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestTypeOf(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(type-of nil)`, Symbol("nil")},
+		{`(type-of true)`, Symbol("bool")},
+		{`(type-of 42)`, Symbol("int")},
+		{`(type-of 100000000000000000000)`, Symbol("int")},
+		{`(type-of 1/2)`, Symbol("rational")},
+		{`(type-of 3.14)`, Symbol("float")},
+		{`(type-of 1+2i)`, Symbol("complex")},
+		{`(type-of "abc")`, Symbol("str")},
+		{`(type-of #\a)`, Symbol("char")},
+		{`(type-of 'foo)`, Symbol("symbol")},
+		{`(type-of :foo)`, Symbol("keyword")},
+		{`(type-of #"a+")`, Symbol("regex")},
+		{`(type-of '(1 2))`, Symbol("list")},
+		{`(type-of (hash-map))`, Symbol("map")},
+		{`(type-of (now))`, Symbol("time")},
+		{`(type-of (duration 1))`, Symbol("duration")},
+		{`(type-of (identity 2))`, Symbol("matrix")},
+		{`(type-of (fn (x) x))`, Symbol("fn")},
+		{`(type-of +)`, Symbol("fn")},
+		{`(type-of (generator (fn () (yield 1))))`, Symbol("iterator")},
+		{`(defrecord Point (x y)) (type-of (->Point 1 2))`, Symbol("Point")},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestMultimethods(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(defrecord Circle (r))
+		  (defrecord Rect (w h))
+		  (defmulti area type-of)
+		  (defmethod area 'Circle (c) (* 3 (Circle-r c) (Circle-r c)))
+		  (defmethod area 'Rect (r) (* (Rect-w r) (Rect-h r)))
+		  (list (area (->Circle 2)) (area (->Rect 2 3)))`, List{Int(12), Int(6)}},
+		{`(defmulti describe type-of)
+		  (defmethod describe 'int (x) "int")
+		  (defmethod describe 'str (x) "string")
+		  (defmethod describe :default (x) "something else")
+		  (list (describe 1) (describe "a") (describe '(1)))`, List{String("int"), String("string"), String("something else")}},
+		// dispatch on the value computed from multiple arguments
+		{`(defmulti combine (fn (x y) (list (type-of x) (type-of y))))
+		  (defmethod combine '(int int) (x y) (+ x y))
+		  (defmethod combine '(str str) (x y) (str x y))
+		  (list (combine 1 2) (combine "a" "b"))`, List{Int(3), String("ab")}},
+		// keyword dispatch values
+		{`(defmulti speak (fn (animal) (get animal :kind)))
+		  (defmethod speak :dog (a) "woof")
+		  (defmethod speak :cat (a) "meow")
+		  (speak (hash-map :kind :cat))`, String("meow")},
+		// redefining the method replaces it
+		{`(defmulti f type-of)
+		  (defmethod f 'int (x) 1)
+		  (defmethod f 'int (x) 2)
+		  (f 0)`, Int(2)},
+		// methods are called in the tail position
+		{`(defmulti countdown (fn (n) (if (= n 0) :done :more)))
+		  (defmethod countdown :done (n) 'finished)
+		  (defmethod countdown :more (n) (countdown (- n 1)))
+		  (countdown 100000)`, Symbol("finished")},
+		{`(defmulti m type-of) (str m)`, String("<multimethod m>")},
+		{`(defmulti m type-of) (fn? m)`, Bool(true)},
+		{`(defmulti m type-of) (defmethod m 'int (x) (* x 2)) (map m '(1 2))`, List{Int(2), Int(4)}},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestMultimethodsErrors(t *testing.T) {
+	var testCases = []string{
+		`(defmulti m)`,
+		`(defmulti "m" type-of)`,
+		`(defmulti m 42)`,
+		`(defmethod m 'int (x) x)`,
+		`(def m 1) (defmethod m 'int (x) x)`,
+		`(defmulti m type-of) (defmethod m 'int x x)`,
+		`(defmulti m type-of) (defmethod m 'int (x))`,
+		`(defmulti m type-of) (defmethod m 'int (x) x) (m "a")`,
+		`(defmulti m type-of) (defmethod m 'int (x) x) (m 1 2)`,
+		`(defmulti m type-of) (defmethod m :default (x y) x) (m 1)`,
+	}
+
+	runErrorTests(t, testCases)
+}
@@ -0,0 +1,430 @@
+package evaluator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"math/cmplx"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// The numeric tower: integers are promoted to big integers on overflow,
+// division of integers gives exact rationals, floats are used only when
+// one of the arguments is a float, and complex numbers when one of the
+// arguments is complex. Results are always normalized,
+// so big integers that fit in Int and rationals with unit denominator
+// are converted back to Int.
+
+const (
+	intLevel = iota
+	bigIntLevel
+	rationalLevel
+	floatLevel
+	complexLevel
+)
+
+const (
+	maxInt = 1<<(bits.UintSize-1) - 1
+	minInt = -1 << (bits.UintSize - 1)
+)
+
+var errDivisionByZero = errors.New("division by zero")
+
+func numericLevel(obj Any) (int, error) {
+	switch obj.(type) {
+	case Int:
+		return intLevel, nil
+	case BigInt:
+		return bigIntLevel, nil
+	case Rational:
+		return rationalLevel, nil
+	case Float:
+		return floatLevel, nil
+	case Complex:
+		return complexLevel, nil
+	default:
+		return 0, &ErrNaN{obj}
+	}
+}
+
+func isNumber(obj Any) bool {
+	_, err := numericLevel(obj)
+	return err == nil
+}
+
+func toBigInt(obj Any) BigInt {
+	switch obj := obj.(type) {
+	case Int:
+		return big.NewInt(int64(obj))
+	case BigInt:
+		return obj
+	default:
+		panic("not an integer")
+	}
+}
+
+func toRational(obj Any) Rational {
+	switch obj := obj.(type) {
+	case Int:
+		return new(big.Rat).SetInt64(int64(obj))
+	case BigInt:
+		return new(big.Rat).SetInt(obj)
+	case Rational:
+		return obj
+	default:
+		panic("not a rational number")
+	}
+}
+
+func floatValue(obj Any) (Float, error) {
+	switch obj := obj.(type) {
+	case Int:
+		return Float(obj), nil
+	case BigInt:
+		f, _ := new(big.Float).SetInt(obj).Float64()
+		return f, nil
+	case Rational:
+		f, _ := obj.Float64()
+		return f, nil
+	case Float:
+		return obj, nil
+	case Complex:
+		return 0, fmt.Errorf("%v is a complex number", obj)
+	default:
+		return 0, &ErrNaN{obj}
+	}
+}
+
+func complexValue(obj Any) (Complex, error) {
+	if c, ok := obj.(Complex); ok {
+		return c, nil
+	}
+	f, err := floatValue(obj)
+	return Complex(complex(f, 0)), err
+}
+
+// normalize converts the number to the lowest possible level of the tower
+func normalize(obj Any) Any {
+	switch obj := obj.(type) {
+	case BigInt:
+		if obj.IsInt64() {
+			if n := obj.Int64(); n >= minInt && n <= maxInt {
+				return Int(n)
+			}
+		}
+		return obj
+	case Rational:
+		if obj.IsInt() {
+			return normalize(new(big.Int).Set(obj.Num()))
+		}
+		return obj
+	default:
+		return obj
+	}
+}
+
+type numericOp struct {
+	// returns false when the result cannot be represented as Int
+	int      func(x, y Int) (Int, bool)
+	bigInt   func(x, y BigInt) (Any, error)
+	rational func(x, y Rational) (Any, error)
+	float    func(x, y Float) Float
+	complex  func(x, y Complex) (Any, error)
+}
+
+func (op *numericOp) apply(x, y Any) (Any, error) {
+	lx, err := numericLevel(x)
+	if err != nil {
+		return nil, err
+	}
+	ly, err := numericLevel(y)
+	if err != nil {
+		return nil, err
+	}
+
+	level := lx
+	if ly > level {
+		level = ly
+	}
+
+	switch level {
+	case intLevel:
+		if res, ok := op.int(x.(Int), y.(Int)); ok {
+			return res, nil
+		}
+		res, err := op.bigInt(toBigInt(x), toBigInt(y))
+		return normalize(res), err
+	case bigIntLevel:
+		res, err := op.bigInt(toBigInt(x), toBigInt(y))
+		return normalize(res), err
+	case rationalLevel:
+		res, err := op.rational(toRational(x), toRational(y))
+		return normalize(res), err
+	case floatLevel:
+		fx, _ := floatValue(x)
+		fy, _ := floatValue(y)
+		return op.float(fx, fy), nil
+	default:
+		cx, _ := complexValue(x)
+		cy, _ := complexValue(y)
+		return op.complex(cx, cy)
+	}
+}
+
+var addOp = &numericOp{
+	func(x, y Int) (Int, bool) {
+		z := x + y
+		return z, (x >= 0) != (y >= 0) || (z >= 0) == (x >= 0)
+	},
+	func(x, y BigInt) (Any, error) {
+		return new(big.Int).Add(x, y), nil
+	},
+	func(x, y Rational) (Any, error) {
+		return new(big.Rat).Add(x, y), nil
+	},
+	func(x, y Float) Float { return x + y },
+	func(x, y Complex) (Any, error) { return x + y, nil },
+}
+
+var subOp = &numericOp{
+	func(x, y Int) (Int, bool) {
+		z := x - y
+		return z, (x >= 0) == (y >= 0) || (z >= 0) == (x >= 0)
+	},
+	func(x, y BigInt) (Any, error) {
+		return new(big.Int).Sub(x, y), nil
+	},
+	func(x, y Rational) (Any, error) {
+		return new(big.Rat).Sub(x, y), nil
+	},
+	func(x, y Float) Float { return x - y },
+	func(x, y Complex) (Any, error) { return x - y, nil },
+}
+
+var mulOp = &numericOp{
+	func(x, y Int) (Int, bool) {
+		if x == 0 || y == 0 {
+			return 0, true
+		}
+		if (x == -1 && y == minInt) || (y == -1 && x == minInt) {
+			return 0, false
+		}
+		z := x * y
+		return z, z/y == x
+	},
+	func(x, y BigInt) (Any, error) {
+		return new(big.Int).Mul(x, y), nil
+	},
+	func(x, y Rational) (Any, error) {
+		return new(big.Rat).Mul(x, y), nil
+	},
+	func(x, y Float) Float { return x * y },
+	func(x, y Complex) (Any, error) { return x * y, nil },
+}
+
+var divOp = &numericOp{
+	func(x, y Int) (Int, bool) {
+		if y == 0 || x%y != 0 || (x == minInt && y == -1) {
+			return 0, false
+		}
+		return x / y, true
+	},
+	func(x, y BigInt) (Any, error) {
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return new(big.Rat).SetFrac(x, y), nil
+	},
+	func(x, y Rational) (Any, error) {
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return new(big.Rat).Quo(x, y), nil
+	},
+	func(x, y Float) Float { return x / y },
+	func(x, y Complex) (Any, error) { return x / y, nil },
+}
+
+var modOp = &numericOp{
+	func(x, y Int) (Int, bool) {
+		if y == 0 || y == -1 {
+			return 0, y == -1
+		}
+		return x % y, true
+	},
+	func(x, y BigInt) (Any, error) {
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return new(big.Int).Rem(x, y), nil
+	},
+	func(x, y Rational) (Any, error) {
+		if y.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		// x - y * trunc(x / y)
+		q := new(big.Rat).Quo(x, y)
+		t := new(big.Int).Quo(q.Num(), q.Denom())
+		z := new(big.Rat).Mul(y, new(big.Rat).SetInt(t))
+		return z.Sub(x, z), nil
+	},
+	math.Mod,
+	func(x, y Complex) (Any, error) {
+		return nil, errors.New("modulo is not defined for complex numbers")
+	},
+}
+
+var powOp = &numericOp{
+	func(x, y Int) (Int, bool) {
+		return 0, false
+	},
+	func(x, y BigInt) (Any, error) {
+		if !y.IsInt64() {
+			return nil, errors.New("exponent is too large")
+		}
+		if y.Sign() >= 0 {
+			return new(big.Int).Exp(x, y, nil), nil
+		}
+		if x.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		z := new(big.Int).Exp(x, new(big.Int).Neg(y), nil)
+		return new(big.Rat).SetFrac(big.NewInt(1), z), nil
+	},
+	func(x, y Rational) (Any, error) {
+		if !y.IsInt() {
+			fx, _ := x.Float64()
+			fy, _ := y.Float64()
+			return math.Pow(fx, fy), nil
+		}
+		n := y.Num()
+		if !n.IsInt64() {
+			return nil, errors.New("exponent is too large")
+		}
+		abs := new(big.Int).Abs(n)
+		num := new(big.Int).Exp(x.Num(), abs, nil)
+		den := new(big.Int).Exp(x.Denom(), abs, nil)
+		if n.Sign() < 0 {
+			num, den = den, num
+		}
+		if den.Sign() == 0 {
+			return nil, errDivisionByZero
+		}
+		return new(big.Rat).SetFrac(num, den), nil
+	},
+	math.Pow,
+	func(x, y Complex) (Any, error) {
+		return Complex(cmplx.Pow(complex128(x), complex128(y))), nil
+	},
+}
+
+// compareNumbers returns -1, 0, or 1 if x is less, equal, or greater than y,
+// the second value is false if the numbers are not comparable (NaN)
+func compareNumbers(x, y Any) (int, bool, error) {
+	lx, err := numericLevel(x)
+	if err != nil {
+		return 0, false, err
+	}
+	ly, err := numericLevel(y)
+	if err != nil {
+		return 0, false, err
+	}
+
+	level := lx
+	if ly > level {
+		level = ly
+	}
+
+	switch level {
+	case intLevel:
+		x, y := x.(Int), y.(Int)
+		switch {
+		case x < y:
+			return -1, true, nil
+		case x > y:
+			return 1, true, nil
+		default:
+			return 0, true, nil
+		}
+	case bigIntLevel:
+		return toBigInt(x).Cmp(toBigInt(y)), true, nil
+	case rationalLevel:
+		return toRational(x).Cmp(toRational(y)), true, nil
+	case complexLevel:
+		return 0, false, errors.New("complex numbers are not ordered")
+	default:
+		fx, _ := floatValue(x)
+		fy, _ := floatValue(y)
+		switch {
+		case fx < fy:
+			return -1, true, nil
+		case fx > fy:
+			return 1, true, nil
+		case fx == fy:
+			return 0, true, nil
+		default:
+			return 0, false, nil
+		}
+	}
+}
+
+func numbersEqual(x, y Any) bool {
+	_, isComplex := x.(Complex)
+	if _, ok := y.(Complex); ok || isComplex {
+		cx, err := complexValue(x)
+		if err != nil {
+			return false
+		}
+		cy, err := complexValue(y)
+		return err == nil && cx == cy
+	}
+	c, ok, err := compareNumbers(x, y)
+	return err == nil && ok && c == 0
+}
+
+func getNumber(obj Any, env *environment.Env) (Any, error) {
+	obj, err := eval(obj, env)
+	if err != nil {
+		return nil, err
+	}
+	if !isNumber(obj) {
+		return nil, &ErrNaN{obj}
+	}
+	return obj, nil
+}
+
+type multiArgNumericFunction struct {
+	op    *numericOp
+	start Int
+}
+
+func (f *multiArgNumericFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	if len(args) == 0 {
+		return f.start, nil
+	}
+
+	num, err := getNumber(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 1 {
+		return f.op.apply(f.start, num)
+	}
+
+	res := num
+	for _, obj := range args[1:] {
+		num, err := getNumber(obj, env)
+		if err != nil {
+			return nil, err
+		}
+		res, err = f.op.apply(res, num)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
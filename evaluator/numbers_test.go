@@ -0,0 +1,154 @@
+package evaluator
+
+import (
+	"math/big"
+	"testing"
+)
+
+func bigInt(s string) BigInt {
+	b, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big integer: " + s)
+	}
+	return b
+}
+
+func TestNumericTower(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(+ 1 2)`, Int(3)},
+		{`(- 1 2)`, Int(-1)},
+		{`(* 2 3 4)`, Int(24)},
+		{`(/ 6 3)`, Int(2)},
+		{`(/ 1 3)`, big.NewRat(1, 3)},
+		{`(/ 3)`, big.NewRat(1, 3)},
+		{`(/ 4 6)`, big.NewRat(2, 3)},
+		{`(+ 1/3 2/3)`, Int(1)},
+		{`(* 1/3 3)`, Int(1)},
+		{`(- 1/2 1/3)`, big.NewRat(1, 6)},
+		{`(+ 1/2 0.5)`, Float(1)},
+		{`(+ 1 2.5)`, Float(3.5)},
+		{`(* 1.0 2)`, Float(2)},
+		{`(/ 1.0 4)`, Float(0.25)},
+		{`(% 7 3)`, Int(1)},
+		{`(% -7 3)`, Int(-1)},
+		{`(% 7.5 2)`, Float(1.5)},
+		{`(% 7/2 1)`, big.NewRat(1, 2)},
+		{`(pow 2 10)`, Int(1024)},
+		{`(pow 2 -2)`, big.NewRat(1, 4)},
+		{`(pow 2/3 2)`, big.NewRat(4, 9)},
+		{`(pow 4 0.5)`, Float(2)},
+		{`(pow 2 64)`, bigInt("18446744073709551616")},
+		{`(+ 9223372036854775807 1)`, bigInt("9223372036854775808")},
+		{`(- -9223372036854775808 1)`, bigInt("-9223372036854775809")},
+		{`(* 9223372036854775807 2)`, bigInt("18446744073709551614")},
+		{`(- (+ 9223372036854775807 1) 1)`, Int(9223372036854775807)},
+		{`(/ (pow 10 30) (pow 10 29))`, Int(10)},
+		{`100000000000000000000`, bigInt("100000000000000000000")},
+		{`(int? 100000000000000000000)`, Bool(true)},
+		{`(int? 1/2)`, Bool(false)},
+		{`(rational? 1/2)`, Bool(true)},
+		{`(rational? 2/2)`, Bool(false)},
+		{`(number? 1/2)`, Bool(true)},
+		{`(number? "1")`, Bool(false)},
+		{`(= 1/2 0.5)`, Bool(true)},
+		{`(= 2/2 1)`, Bool(true)},
+		{`(= (pow 2 64) (pow 2 64))`, Bool(true)},
+		{`(= (list 1/2 (pow 2 64)) (list 1/2 (pow 2 64)))`, Bool(true)},
+		{`(< 1/3 1/2)`, Bool(true)},
+		{`(> (pow 2 64) 1)`, Bool(true)},
+		{`(< 1/3 0.3)`, Bool(false)},
+		{`(float 1/4)`, Float(0.25)},
+		{`(int 7/2)`, Int(3)},
+		{`(str 1/3)`, String("1/3")},
+		{`(sqrt 1/4)`, Float(0.5)},
+		{`(nan? 1/4)`, Bool(false)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestNumericTowerErrors(t *testing.T) {
+	var testCases = []string{
+		`(/ 1 0)`,
+		`(/ 1/2 0)`,
+		`(% 1 0)`,
+		`(pow 0 -1)`,
+		`(+ 1 "2")`,
+		`(conj 5)`,
+		`(conjugate "a")`,
+		`(< 1 "2")`,
+	}
+
+	runErrorTests(t, testCases)
+}
+
+func TestComplexNumbers(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`3+4i`, Complex(3 + 4i)},
+		{`-2.5i`, Complex(-2.5i)},
+		{`(complex 1 2)`, Complex(1 + 2i)},
+		{`(+ 1+2i 3)`, Complex(4 + 2i)},
+		{`(+ 1+2i 1/2)`, Complex(1.5 + 2i)},
+		{`(- 1+2i 1+2i)`, Complex(0)},
+		{`(* 1i 1i)`, Complex(-1)},
+		{`(/ 4+2i 2)`, Complex(2 + 1i)},
+		{`(real 3+4i)`, Float(3)},
+		{`(imag 3+4i)`, Float(4)},
+		{`(real 3)`, Int(3)},
+		{`(imag 3)`, Int(0)},
+		{`(abs 3+4i)`, Float(5)},
+		{`(abs -3)`, Int(3)},
+		{`(abs -1/2)`, big.NewRat(1, 2)},
+		{`(abs -9223372036854775808)`, bigInt("9223372036854775808")},
+		{`(phase 1i)`, Float(1.5707963267948966)},
+		{`(conjugate 3+4i)`, Complex(3 - 4i)},
+		{`(conjugate 3)`, Int(3)},
+		{`(conjugate '(1+1i 2))`, List{Complex(1 - 1i), Int(2)}},
+		{`(conj '(1) 2)`, List{Int(1), Int(2)}},
+		{`(sqrt -4+0i)`, Complex(2i)},
+		{`(exp 0i)`, Complex(1)},
+		{`(sqrt 4)`, Float(2)},
+		{`(= 1+0i 1)`, Bool(true)},
+		{`(= 1+1i 1)`, Bool(false)},
+		{`(complex? 1i)`, Bool(true)},
+		{`(complex? 1)`, Bool(false)},
+		{`(str 3+4i)`, String("3+4i")},
+		{`(str (* 2 -1.5i))`, String("0-3i")},
+	}
+
+	runTests(testCases, t)
+
+	runErrorTests(t, []string{`(< 1i 2i)`, `(% 1i 2)`, `(floor 1i)`})
+}
+
+func TestBitwise(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`0x1F`, Int(31)},
+		{`0b1010`, Int(10)},
+		{`0o755`, Int(493)},
+		{`1_000_000`, Int(1000000)},
+		{`-0xff`, Int(-255)},
+		{`(int "0x1F")`, Int(31)},
+		{`(bit-and 0b1100 0b1010)`, Int(8)},
+		{`(bit-and 0xff)`, Int(255)},
+		{`(bit-or 0b1100 0b1010)`, Int(14)},
+		{`(bit-xor 0b1100 0b1010)`, Int(6)},
+		{`(bit-not 0)`, Int(-1)},
+		{`(shift-left 1 10)`, Int(1024)},
+		{`(shift-left 1 64)`, Int(0)},
+		{`(shift-left 0x4000000000000000 1)`, Int(-9223372036854775808)},
+		{`(shift-right 1024 3)`, Int(128)},
+		{`(shift-right -16 2)`, Int(-4)},
+		{`(bit-count 0b1011)`, Int(3)},
+		{`(bit-count -1)`, Int(64)},
+		{`(int->hex 31)`, String("0x1f")},
+		{`(int->hex -255)`, String("-0xff")},
+		{`(int->bin 10)`, String("0b1010")},
+		{`(int->hex (pow 2 64))`, String("0x10000000000000000")},
+		{`(= (parse-string (int->hex 12345)) 12345)`, Bool(true)},
+	}
+
+	runTests(testCases, t)
+
+	runErrorTests(t, []string{`(bit-and 1.0 1)`, `(shift-left 1 -1)`, `(bit-not 1/2)`, `(int->bin 1.5)`})
+}
@@ -0,0 +1,257 @@
+package evaluator
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// random holds the pseudo-random number generator of the evaluator,
+// so that the concurrent evaluators do not share the state
+type random struct {
+	rng *rand.Rand
+}
+
+func newRandom() *random {
+	return &random{rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))}
+}
+
+func seededRand(seed Int) *rand.Rand {
+	return rand.New(rand.NewPCG(uint64(seed), 0))
+}
+
+// randomEnv returns the environment with the random number builtins
+// using the generator that is private for the evaluator
+func randomEnv(parent *environment.Env) *environment.Env {
+	env := environment.NewEnv(parent)
+	for name, obj := range newRandom().buildins() {
+		env.Set(name, obj)
+	}
+	return env
+}
+
+func (r *random) buildins() map[Symbol]Any {
+	return map[Symbol]Any{
+		"rand": &multiArgFunction{
+			// (rand [<max>])
+			r.randFn,
+		},
+		"rand-int": &multiArgFunction{
+			// (rand-int [<min>] <max>)
+			r.randIntFn,
+		},
+		"rand-normal": &multiArgFunction{
+			// (rand-normal [<mean> <stdev>])
+			r.randNormalFn,
+		},
+		"shuffle": &singleArgFunction{
+			// (shuffle <list>)
+			r.shuffleFn,
+		},
+		"rand-nth": &singleArgFunction{
+			// (rand-nth <list>)
+			r.randNthFn,
+		},
+		"sample": &multiArgFunction{
+			// (sample <list> <n> [:replace <bool>])
+			r.sampleFn,
+		},
+		"set-seed!": &singleArgFunction{
+			// (set-seed! <int>)
+			r.setSeedFn,
+		},
+		"with-seed": &simpleFunction{
+			// (with-seed <int> <expr>...)
+			r.withSeedFn,
+		},
+	}
+}
+
+func getSeed(obj Any) (Int, error) {
+	seed, ok := obj.(Int)
+	if !ok {
+		return 0, &ErrWrongType{obj}
+	}
+	return seed, nil
+}
+
+func getPositiveInt(obj Any) (Int, error) {
+	n, ok := obj.(Int)
+	if !ok {
+		return 0, &ErrWrongType{obj}
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("%v is not a positive integer", n)
+	}
+	return n, nil
+}
+
+// randFn returns the random float from [0, 1), or from [0, hi)
+func (r *random) randFn(objs []Any) (Any, error) {
+	switch len(objs) {
+	case 0:
+		return Float(r.rng.Float64()), nil
+	case 1:
+		hi, err := floatValue(objs[0])
+		if err != nil {
+			return nil, err
+		}
+		return Float(r.rng.Float64()) * hi, nil
+	default:
+		return nil, &ErrNumArgs{len(objs)}
+	}
+}
+
+// randIntFn returns the random integer from [0, hi), or from [lo, hi)
+func (r *random) randIntFn(objs []Any) (Any, error) {
+	var lo, hi Int
+	switch len(objs) {
+	case 1:
+		n, err := getPositiveInt(objs[0])
+		if err != nil {
+			return nil, err
+		}
+		hi = n
+	case 2:
+		var ok bool
+		if lo, ok = objs[0].(Int); !ok {
+			return nil, &ErrWrongType{objs[0]}
+		}
+		if hi, ok = objs[1].(Int); !ok {
+			return nil, &ErrWrongType{objs[1]}
+		}
+		if lo >= hi {
+			return nil, fmt.Errorf("empty range [%v, %v)", lo, hi)
+		}
+	default:
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	width := hi - lo
+	if width < 0 {
+		// the width of the range overflows, but it fits in uint64
+		return lo + Int(r.rng.Uint64N(uint64(hi)-uint64(lo))), nil
+	}
+	return lo + Int(r.rng.Int64N(int64(width))), nil
+}
+
+// randNormalFn returns the random number from the normal distribution,
+// the standard normal by default
+func (r *random) randNormalFn(objs []Any) (Any, error) {
+	switch len(objs) {
+	case 0:
+		return Float(r.rng.NormFloat64()), nil
+	case 2:
+		mean, err := floatValue(objs[0])
+		if err != nil {
+			return nil, err
+		}
+		stdev, err := floatValue(objs[1])
+		if err != nil {
+			return nil, err
+		}
+		if stdev < 0 {
+			return nil, fmt.Errorf("negative standard deviation %v", stdev)
+		}
+		return mean + Float(r.rng.NormFloat64())*stdev, nil
+	default:
+		return nil, &ErrNumArgs{len(objs)}
+	}
+}
+
+func (r *random) shuffleFn(obj Any) (Any, error) {
+	l, ok := obj.(List)
+	if !ok {
+		return nil, &ErrWrongType{obj}
+	}
+	result := make(List, len(l))
+	copy(result, l)
+	r.rng.Shuffle(len(result), func(i, j int) {
+		result[i], result[j] = result[j], result[i]
+	})
+	return result, nil
+}
+
+func (r *random) randNthFn(obj Any) (Any, error) {
+	l, ok := obj.(List)
+	if !ok {
+		return nil, &ErrWrongType{obj}
+	}
+	if len(l) == 0 {
+		return nil, fmt.Errorf("cannot pick from an empty list")
+	}
+	return l[r.rng.IntN(len(l))], nil
+}
+
+// sampleFn draws n elements of the list without replacement, unless :replace is true
+func (r *random) sampleFn(objs []Any) (Any, error) {
+	if len(objs) < 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	l, ok := objs[0].(List)
+	if !ok {
+		return nil, &ErrWrongType{objs[0]}
+	}
+	n, ok := objs[1].(Int)
+	if !ok || n < 0 {
+		return nil, &ErrWrongType{objs[1]}
+	}
+	opts, err := getOptions(objs[2:], ":replace")
+	if err != nil {
+		return nil, err
+	}
+
+	result := List{}
+	if isTrue(opts[":replace"]) {
+		if n > 0 && len(l) == 0 {
+			return nil, fmt.Errorf("cannot sample from an empty list")
+		}
+		for i := 0; i < int(n); i++ {
+			result = append(result, l[r.rng.IntN(len(l))])
+		}
+		return result, nil
+	}
+
+	if int(n) > len(l) {
+		return nil, fmt.Errorf("cannot sample %v elements from %d without replacement", n, len(l))
+	}
+	for _, i := range r.rng.Perm(len(l))[:n] {
+		result = append(result, l[i])
+	}
+	return result, nil
+}
+
+func (r *random) setSeedFn(obj Any) (Any, error) {
+	seed, err := getSeed(obj)
+	if err != nil {
+		return nil, err
+	}
+	r.rng = seededRand(seed)
+	return nil, nil
+}
+
+// withSeedFn evaluates the body using the generator with the seed,
+// and restores the previous generator afterwards
+func (r *random) withSeedFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) < 1 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	obj, err := eval(args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := getSeed(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	prev := r.rng
+	r.rng = seededRand(seed)
+	defer func() { r.rng = prev }()
+
+	objs, err := evalAll(args[1:], env)
+	if err != nil {
+		return nil, err
+	}
+	return last(objs), nil
+}
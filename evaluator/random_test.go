@@ -0,0 +1,116 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(float? (rand))`, Bool(true)},
+		{`(let (x (rand)) (and (not (< x 0)) (< x 1)))`, Bool(true)},
+		{`(let (x (rand 10)) (and (not (< x 0)) (< x 10)))`, Bool(true)},
+		{`(let (x (rand-int 3)) (and (int? x) (not (< x 0)) (< x 3)))`, Bool(true)},
+		{`(let (x (rand-int -5 -2)) (and (> x -6) (< x -2)))`, Bool(true)},
+		{`(float? (rand-normal))`, Bool(true)},
+		{`(rand-normal 5 0)`, Float(5)},
+		{`(rand-int 1)`, Int(0)},
+		{`(int? (rand-int -9223372036854775808 9223372036854775807))`, Bool(true)},
+		{`(rand-int 9223372036854775806 9223372036854775807)`, Int(9223372036854775806)},
+		{`(let (x (rand-int -9223372036854775808 1)) (< x 1))`, Bool(true)},
+		{`(rand-nth '(42))`, Int(42)},
+		{`(count (shuffle '(1 2 3 4 5)))`, Int(5)},
+		{`(shuffle '())`, List{}},
+		{`(apply + (shuffle '(1 2 3 4)))`, Int(10)},
+		{`(apply + (sample '(1 2 3 4) 4))`, Int(10)},
+		{`(count (sample '(1 2 3 4) 2))`, Int(2)},
+		{`(sample '(1 2 3) 0)`, List{}},
+		{`(sample '(1) 3 :replace true)`, List{Int(1), Int(1), Int(1)}},
+		// the same seed gives the same results
+		{`(= (with-seed 42 (rand) (rand)) (with-seed 42 (rand) (rand)))`, Bool(true)},
+		{`(= (with-seed 42 (shuffle '(1 2 3 4 5 6 7 8 9 10)))
+		     (with-seed 42 (shuffle '(1 2 3 4 5 6 7 8 9 10))))`, Bool(true)},
+		{`(= (with-seed 1 (rand)) (with-seed 2 (rand)))`, Bool(false)},
+		{`(def a (begin (set-seed! 7) (list (rand-int 100) (rand-normal) (sample '(1 2 3 4 5) 2))))
+		  (def b (begin (set-seed! 7) (list (rand-int 100) (rand-normal) (sample '(1 2 3 4 5) 2))))
+		  (= a b)`, Bool(true)},
+		// with-seed restores the previous generator
+		{`(set-seed! 7)
+		  (def a (rand))
+		  (set-seed! 7)
+		  (with-seed 1 (rand) (rand))
+		  (= a (rand))`, Bool(true)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestRandomPerEvaluator(t *testing.T) {
+	first := NewEvaluator()
+	second := NewEvaluator()
+
+	if _, err := first.EvalString(`(set-seed! 42)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := second.EvalString(`(set-seed! 42)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// drawing from one of the generators does not affect the other
+	if _, err := first.EvalString(`(rand) (rand) (rand)`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	x, err := second.EvalString(`(rand)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	y, err := NewEvaluator().EvalString(`(set-seed! 42) (rand)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last(x) != last(y) {
+		t.Errorf("expected %v, got %v", last(y), last(x))
+	}
+}
+
+func TestWithSeedRestoresOnError(t *testing.T) {
+	e := NewEvaluator()
+	x, err := e.EvalString(`(set-seed! 7) (rand)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := e.EvalString(`(set-seed! 7) (with-seed 1 (rand) (error "oops"))`); err == nil {
+		t.Fatal("expected an error")
+	}
+	y, err := e.EvalString(`(rand)`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if last(x) != last(y) {
+		t.Errorf("expected %v, got %v", last(x), last(y))
+	}
+}
+
+func TestRandomErrors(t *testing.T) {
+	var testCases = []string{
+		`(rand "a")`,
+		`(rand 1 2)`,
+		`(rand-int 0)`,
+		`(rand-int -1)`,
+		`(rand-int 1.5)`,
+		`(rand-int 5 5)`,
+		`(rand-normal 1)`,
+		`(rand-normal 0 -1)`,
+		`(rand-nth '())`,
+		`(rand-nth "abc")`,
+		`(shuffle 1)`,
+		`(sample '(1 2) 3)`,
+		`(sample '() 1 :replace true)`,
+		`(sample '(1 2) -1)`,
+		`(sample '(1 2) 1 :unknown true)`,
+		`(set-seed! 1.5)`,
+		`(with-seed "a" (rand))`,
+		`(with-seed)`,
+	}
+
+	runErrorTests(t, testCases)
+}
@@ -0,0 +1,129 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/twolodzko/gol/environment"
+)
+
+// defrecordFn defines the record type, with the ->Name constructor taking
+// the values of the fields, the map->Name constructor taking the map with
+// keyword keys, the Name? predicate, and the Name-field accessors
+func defrecordFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 2 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	name, ok := args[0].(Symbol)
+	if !ok || isKeyword(name) {
+		return nil, &ErrWrongType{args[0]}
+	}
+	fields, ok := args[1].(List)
+	if !ok {
+		return nil, &ErrWrongType{args[1]}
+	}
+
+	t := &RecordType{Name: name}
+	for _, obj := range fields {
+		field, ok := obj.(Symbol)
+		if !ok || isKeyword(field) {
+			return nil, fmt.Errorf("invalid field name %v", obj)
+		}
+		if containsSymbol(t.Fields, field) {
+			return nil, fmt.Errorf("duplicate field %v", field)
+		}
+		t.Fields = append(t.Fields, field)
+	}
+
+	env.Set("->"+name, &multiArgFunction{
+		func(objs []Any) (Any, error) {
+			if len(objs) != len(t.Fields) {
+				return nil, &ErrNumArgs{len(objs)}
+			}
+			values := make([]Any, len(objs))
+			copy(values, objs)
+			return Record{Type: t, Values: values}, nil
+		},
+	})
+	env.Set("map->"+name, &singleArgFunction{
+		func(obj Any) (Any, error) {
+			return recordFromMap(t, obj)
+		},
+	})
+	env.Set(name+"?", &singleArgFunction{
+		func(obj Any) (Any, error) {
+			r, ok := obj.(Record)
+			return Bool(ok && r.Type == t), nil
+		},
+	})
+	for i, field := range t.Fields {
+		i := i
+		env.Set(name+"-"+field, &singleArgFunction{
+			func(obj Any) (Any, error) {
+				r, ok := obj.(Record)
+				if !ok || r.Type != t {
+					return nil, fmt.Errorf("%v is not a %s record", obj, t.Name)
+				}
+				return r.Values[i], nil
+			},
+		})
+	}
+
+	return name, nil
+}
+
+// recordFromMap creates the record from the map with the keyword keys,
+// the missing fields are nil
+func recordFromMap(t *RecordType, obj Any) (Any, error) {
+	m, err := getMap(obj)
+	if err != nil {
+		return nil, err
+	}
+	r := Record{Type: t, Values: make([]Any, len(t.Fields))}
+	for k, v := range m {
+		i, err := fieldIndex(r, k)
+		if err != nil {
+			return nil, err
+		}
+		r.Values[i] = v
+	}
+	return r, nil
+}
+
+// fieldIndex returns the index of the field given as a keyword, e.g. :x
+func fieldIndex(r Record, key Any) (int, error) {
+	if k, ok := key.(Symbol); ok && isKeyword(k) {
+		field := Symbol(strings.TrimPrefix(string(k), ":"))
+		for i, name := range r.Type.Fields {
+			if name == field {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%s record has no field %v", r.Type.Name, key)
+}
+
+// assocRecord returns the copy of the record with the fields changed
+func assocRecord(r Record, objs []Any) (Any, error) {
+	if len(objs)%2 != 0 {
+		return nil, fmt.Errorf("missing value for the key %v", objs[len(objs)-1])
+	}
+	out := Record{Type: r.Type, Values: make([]Any, len(r.Values))}
+	copy(out.Values, r.Values)
+	for i := 0; i < len(objs); i += 2 {
+		j, err := fieldIndex(r, objs[i])
+		if err != nil {
+			return nil, err
+		}
+		out.Values[j] = objs[i+1]
+	}
+	return out, nil
+}
+
+func recordToMap(r Record) Map {
+	m := make(Map, len(r.Values))
+	for i, field := range r.Type.Fields {
+		m[":"+field] = r.Values[i]
+	}
+	return m
+}
@@ -0,0 +1,67 @@
+package evaluator
+
+import (
+	"testing"
+)
+
+func TestRecords(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(defrecord Point (x y))`, Symbol("Point")},
+		{`(defrecord Point (x y)) (str (->Point 1 2))`, String("#Point{:x 1 :y 2}")},
+		{`(defrecord Empty ()) (str (->Empty))`, String("#Empty{}")},
+		{`(defrecord Point (x y)) (Point-x (->Point 1 2))`, Int(1)},
+		{`(defrecord Point (x y)) (Point-y (->Point 1 (+ 1 1)))`, Int(2)},
+		{`(defrecord Point (x y)) (Point? (->Point 1 2))`, Bool(true)},
+		{`(defrecord Point (x y)) (Point? '(1 2))`, Bool(false)},
+		{`(defrecord Point (x y)) (defrecord Other (x y)) (Point? (->Other 1 2))`, Bool(false)},
+		{`(defrecord Point (x y)) (record? (->Point 1 2))`, Bool(true)},
+		{`(record? (hash-map :x 1))`, Bool(false)},
+		// structural equality
+		{`(defrecord Point (x y)) (= (->Point 1 '(2)) (->Point 1 '(2)))`, Bool(true)},
+		{`(defrecord Point (x y)) (= (->Point 1 2) (->Point 1 3))`, Bool(false)},
+		{`(defrecord Point (x y)) (defrecord Other (x y)) (= (->Point 1 2) (->Other 1 2))`, Bool(false)},
+		{`(defrecord Point (x y)) (= (->Point 1 2) (hash-map :x 1 :y 2))`, Bool(false)},
+		// maps and records
+		{`(defrecord Point (x y)) (get (->Point 1 2) :y)`, Int(2)},
+		{`(defrecord Point (x y)) (get (->Point 1 2) :z)`, nil},
+		{`(defrecord Point (x y)) (get (->Point 1 2) :z 0)`, Int(0)},
+		{`(defrecord Point (x y)) (contains? (->Point 1 2) :x)`, Bool(true)},
+		{`(defrecord Point (x y)) (contains? (->Point 1 2) :z)`, Bool(false)},
+		{`(defrecord Point (x y)) (str (map->Point (hash-map :y 2)))`, String("#Point{:x <nil> :y 2}")},
+		{`(defrecord Point (x y)) (record->map (->Point 1 2))`, Map{Symbol(":x"): Int(1), Symbol(":y"): Int(2)}},
+		// records are immutable, assoc returns the updated copy
+		{`(defrecord Point (x y))
+		  (def p (->Point 1 2))
+		  (def q (assoc p :x 10 :y 20))
+		  (str p " " q)`, String("#Point{:x 1 :y 2} #Point{:x 10 :y 20}")},
+		{`(defrecord Point (x y))
+		  (def (norm2 p) (+ (* (Point-x p) (Point-x p)) (* (Point-y p) (Point-y p))))
+		  (norm2 (->Point 3 4))`, Int(25)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestRecordsErrors(t *testing.T) {
+	var testCases = []string{
+		`(defrecord Point)`,
+		`(defrecord "Point" (x y))`,
+		`(defrecord :Point (x y))`,
+		`(defrecord Point x)`,
+		`(defrecord Point (x 1))`,
+		`(defrecord Point (x :y))`,
+		`(defrecord Point (x x))`,
+		`(defrecord Point (x y)) (->Point 1)`,
+		`(defrecord Point (x y)) (->Point 1 2 3)`,
+		`(defrecord Point (x y)) (Point-x '(1 2))`,
+		`(defrecord Point (x y)) (defrecord Other (x y)) (Point-x (->Other 1 2))`,
+		`(defrecord Point (x y)) (assoc (->Point 1 2) :z 3)`,
+		`(defrecord Point (x y)) (assoc (->Point 1 2) 'x 3)`,
+		`(defrecord Point (x y)) (assoc (->Point 1 2) :x)`,
+		`(defrecord Point (x y)) (map->Point (hash-map :z 1))`,
+		`(defrecord Point (x y)) (map->Point '(1 2))`,
+		`(record->map (hash-map :x 1))`,
+	}
+
+	runErrorTests(t, testCases)
+}
@@ -108,6 +108,9 @@ func letFn(args []Any, env *environment.Env) (Any, *environment.Env, error) {
 	if len(args) < 2 {
 		return nil, env, &ErrNumArgs{len(args)}
 	}
+	if _, ok := args[0].(Symbol); ok {
+		return namedLet(args, env)
+	}
 
 	localEnv := environment.NewEnv(env)
 
@@ -0,0 +1,148 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/twolodzko/gol/environment"
+	"github.com/twolodzko/gol/parser"
+)
+
+func getRegex(obj Any) (Regex, error) {
+	switch obj := obj.(type) {
+	case Regex:
+		return obj, nil
+	case String:
+		// the patterns use the same escape sequences as the strings,
+		// so they are not unquoted, e.g. "\d" stays a digit class
+		return parser.ParseRegex(string(obj))
+	default:
+		return Regex{}, fmt.Errorf("%v (%T) is not a regular expression", obj, obj)
+	}
+}
+
+// regexFunction takes a regular expression and a string as arguments
+type regexFunction struct {
+	fn func(Regex, string) (Any, error)
+}
+
+func (f *regexFunction) Eval(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 2 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	objs, err := evalAll(args, env)
+	if err != nil {
+		return nil, err
+	}
+	re, err := getRegex(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	str, err := getText(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	return f.fn(re, str)
+}
+
+func reFind(re Regex, str string) (Any, error) {
+	loc := re.FindStringIndex(str)
+	if loc == nil {
+		return nil, nil
+	}
+	return newText(str[loc[0]:loc[1]]), nil
+}
+
+// reMatches returns the string if it matches the regular expression as a whole
+func reMatches(re Regex, str string) (Any, error) {
+	if !re.Anchored.MatchString(str) {
+		return nil, nil
+	}
+	return newText(str), nil
+}
+
+func reSeq(re Regex, str string) (Any, error) {
+	out := List{}
+	for _, m := range re.FindAllString(str, -1) {
+		out = append(out, newText(m))
+	}
+	return out, nil
+}
+
+// reGroups returns the groups of the first match as association list,
+// the keys are the names of the named groups, or the indexes of the groups
+func reGroups(re Regex, str string) (Any, error) {
+	loc := re.FindStringSubmatchIndex(str)
+	if loc == nil {
+		return nil, nil
+	}
+
+	out := List{}
+	for i, name := range re.SubexpNames() {
+		var key Any = Int(i)
+		if name != "" {
+			key = newText(name)
+		}
+		var val Any
+		if loc[2*i] >= 0 {
+			val = newText(str[loc[2*i]:loc[2*i+1]])
+		}
+		out = append(out, List{key, val})
+	}
+	return out, nil
+}
+
+func reSplit(re Regex, str string) (Any, error) {
+	out := List{}
+	for _, s := range re.Split(str, -1) {
+		out = append(out, newText(s))
+	}
+	return out, nil
+}
+
+func reReplaceFn(args []Any, env *environment.Env) (Any, error) {
+	if len(args) != 3 {
+		return nil, &ErrNumArgs{len(args)}
+	}
+	objs, err := evalAll(args, env)
+	if err != nil {
+		return nil, err
+	}
+	re, err := getRegex(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	str, err := getText(objs[1])
+	if err != nil {
+		return nil, err
+	}
+
+	switch repl := objs[2].(type) {
+	case String:
+		text, err := getText(repl)
+		if err != nil {
+			return nil, err
+		}
+		// $1, ${name} are expanded to the submatches
+		return newText(re.ReplaceAllString(str, text)), nil
+	case function:
+		var fnErr error
+		out := re.ReplaceAllStringFunc(str, func(m string) string {
+			if fnErr != nil {
+				return m
+			}
+			res, err := callFunction(repl, []Any{newText(m)}, env)
+			if err != nil {
+				fnErr = err
+				return m
+			}
+			s, err := toText([]Any{res}, "")
+			if err != nil {
+				fnErr = err
+			}
+			return s
+		})
+		return newText(out), fnErr
+	default:
+		return nil, &ErrWrongType{objs[2]}
+	}
+}
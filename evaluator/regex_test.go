@@ -0,0 +1,66 @@
+package evaluator
+
+import "testing"
+
+func TestRegex(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(regex? #"\d+")`, Bool(true)},
+		{`(regex? "\d+")`, Bool(false)},
+		{`(regex? (regex "\d+"))`, Bool(true)},
+		{`(str #"\d+")`, String(`#\"\\d+\"`)},
+		{`(= #"a+" (regex "a+"))`, Bool(true)},
+		{`(re-find #"\d+" "abc 123 def 45")`, String("123")},
+		{`(re-find #"\d+" "abc")`, nil},
+		{`(re-find "b+" "abbbc")`, String("bbb")},
+		{`(re-matches #"\d+" "123")`, String("123")},
+		{`(re-matches #"\d+" "123a")`, nil},
+		{`(re-matches #"a|ab" "ab")`, String("ab")},
+		{`(re-matches (regex "a|ab") "ab")`, String("ab")},
+		{`(re-matches #"(?i)a|b" "B")`, String("B")},
+		{`(re-matches #"a|b" "ab")`, nil},
+		{`(re-seq #"\d+" "1 22 333")`, List{String("1"), String("22"), String("333")}},
+		{`(re-seq #"\d+" "abc")`, List{}},
+		{`(re-groups #"(?P<key>\w+)=(\d+)?" "x=")`, List{
+			List{Int(0), String("x=")},
+			List{String("key"), String("x")},
+			List{Int(2), nil},
+		}},
+		{`(re-groups #"(\w+)@(\w+)" "mail: john@example")`, List{
+			List{Int(0), String("john@example")},
+			List{Int(1), String("john")},
+			List{Int(2), String("example")},
+		}},
+		{`(re-groups #"\d" "abc")`, nil},
+		{`(re-replace #"(\w+)@(\w+)" "john@example" "$2 at ${1}")`, String("example at john")},
+		{`(re-replace #"\d+" "a1b22c" (fn (m) (* 2 (int m))))`, String("a2b44c")},
+		{`(re-replace #"[aeiou]" "hello" upper)`, String("hEllO")},
+		{`(re-split #"\s*,\s*" "a , b,c")`, List{String("a"), String("b"), String("c")}},
+		// the strings are unquoted before matching, and the results are escaped
+		{`(re-split #"\n" "a\nb")`, List{String("a"), String("b")}},
+		{`(re-split (regex "\t") "a\tb")`, List{String("a"), String("b")}},
+		{`(upper (re-find #"\"" "say \"hi\""))`, String(`\"`)},
+		{`(re-seq #"\"\w+\"" "say \"hi\" \"bye\"")`, List{String(`\"hi\"`), String(`\"bye\"`)}},
+		{`(re-matches #"a\\b" "a\\b")`, String(`a\\b`)},
+		{`(re-matches #"a.b" "a\nb")`, nil},
+		{`(re-groups #"(\w)\n" "x\n")`, List{List{Int(0), String(`x\n`)}, List{Int(1), String("x")}}},
+		{`(re-replace #"\n" "a\nb" "\"")`, String(`a\"b`)},
+		{`(re-replace #"\"" "a\"b" (fn (m) (str m m)))`, String(`a\"\"b`)},
+		{`(re-replace #"b" "abc" (fn (m) #\newline))`, String(`a\nc`)},
+		{`(let (re #"(\d+)-(\d+)")
+			(map (fn (line) (nth (nth (re-groups re line) 2) 1)) '("1-2" "3-4")))`, List{String("2"), String("4")}},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestRegexErrors(t *testing.T) {
+	var testCases = []string{
+		`#"(unclosed"`,
+		`(regex "(")`,
+		`(re-find 42 "abc")`,
+		`(re-find #"a" 42)`,
+		`(re-replace #"a" "abc" (fn (m) (error "oops")))`,
+	}
+
+	runErrorTests(t, testCases)
+}
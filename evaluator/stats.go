@@ -0,0 +1,302 @@
+package evaluator
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// vectorized applies the function element-wise when called with a list
+func vectorized(fn func(Any) (Any, error)) func(Any) (Any, error) {
+	return func(obj Any) (Any, error) {
+		l, ok := obj.(List)
+		if !ok {
+			return fn(obj)
+		}
+		result := make(List, len(l))
+		for i, x := range l {
+			y, err := fn(x)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = y
+		}
+		return result, nil
+	}
+}
+
+// getList returns the list, or collects the elements of the iterator
+func getList(obj Any) (List, error) {
+	switch obj := obj.(type) {
+	case List:
+		return obj, nil
+	case iterator:
+		return collect(obj)
+	default:
+		return nil, &ErrWrongType{obj}
+	}
+}
+
+// getFloats converts the elements of the list to floats
+func getFloats(obj Any) ([]float64, error) {
+	l, err := getList(obj)
+	if err != nil {
+		return nil, err
+	}
+	nums := make([]float64, len(l))
+	for i, x := range l {
+		num, err := floatValue(x)
+		if err != nil {
+			return nil, err
+		}
+		nums[i] = num
+	}
+	return nums, nil
+}
+
+// sumFn adds the elements of the list, preserving the numeric tower
+func sumFn(obj Any) (Any, error) {
+	l, err := getList(obj)
+	if err != nil {
+		return nil, err
+	}
+	var total Any = Int(0)
+	for _, x := range l {
+		total, err = addOp.apply(total, x)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+func cumsumFn(obj Any) (Any, error) {
+	l, err := getList(obj)
+	if err != nil {
+		return nil, err
+	}
+	result := make(List, len(l))
+	var total Any = Int(0)
+	for i, x := range l {
+		total, err = addOp.apply(total, x)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = total
+	}
+	return result, nil
+}
+
+func meanFn(obj Any) (Any, error) {
+	nums, err := getFloats(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(nums) == 0 {
+		return nil, fmt.Errorf("mean of an empty list")
+	}
+	return Float(mean(nums)), nil
+}
+
+func mean(nums []float64) float64 {
+	var total float64
+	for _, x := range nums {
+		total += x
+	}
+	return total / float64(len(nums))
+}
+
+// variance is the sample variance, with n-1 in the denominator
+func variance(nums []float64) (float64, error) {
+	if len(nums) < 2 {
+		return 0, fmt.Errorf("variance needs at least two values, got %d", len(nums))
+	}
+	m := mean(nums)
+	var ss float64
+	for _, x := range nums {
+		ss += (x - m) * (x - m)
+	}
+	return ss / float64(len(nums)-1), nil
+}
+
+func varianceFn(obj Any) (Any, error) {
+	nums, err := getFloats(obj)
+	if err != nil {
+		return nil, err
+	}
+	v, err := variance(nums)
+	return Float(v), err
+}
+
+func stdevFn(obj Any) (Any, error) {
+	nums, err := getFloats(obj)
+	if err != nil {
+		return nil, err
+	}
+	v, err := variance(nums)
+	return Float(math.Sqrt(v)), err
+}
+
+// quantile of the sorted values, linearly interpolated between the closest ranks
+func quantile(sorted []float64, q float64) (float64, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return 0, fmt.Errorf("quantile %v is not in [0, 1]", q)
+	}
+	h := float64(len(sorted)-1) * q
+	lo := math.Floor(h)
+	i := int(lo)
+	if i+1 >= len(sorted) {
+		return sorted[i], nil
+	}
+	return sorted[i] + (h-lo)*(sorted[i+1]-sorted[i]), nil
+}
+
+func sortedFloats(obj Any) ([]float64, error) {
+	nums, err := getFloats(obj)
+	if err != nil {
+		return nil, err
+	}
+	if len(nums) == 0 {
+		return nil, fmt.Errorf("quantile of an empty list")
+	}
+	sort.Float64s(nums)
+	return nums, nil
+}
+
+func medianFn(obj Any) (Any, error) {
+	nums, err := sortedFloats(obj)
+	if err != nil {
+		return nil, err
+	}
+	m, err := quantile(nums, 0.5)
+	return Float(m), err
+}
+
+// quantileFn returns the quantile, or the list of quantiles
+// when called with a list of probabilities
+func quantileFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	nums, err := sortedFloats(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	return vectorized(func(obj Any) (Any, error) {
+		q, err := floatValue(obj)
+		if err != nil {
+			return nil, err
+		}
+		v, err := quantile(nums, q)
+		return Float(v), err
+	})(objs[1])
+}
+
+// extremum returns the element of the list, or of the arguments,
+// for which the comparison holds against all the other elements
+func extremum(objs []Any, check func(int) bool) (Any, error) {
+	if len(objs) == 1 {
+		switch obj := objs[0].(type) {
+		case List:
+			objs = obj
+		case iterator:
+			l, err := collect(obj)
+			if err != nil {
+				return nil, err
+			}
+			objs = l
+		}
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("no values to compare")
+	}
+
+	best := objs[0]
+	for _, obj := range objs[1:] {
+		c, ok, err := compare(obj, best)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("cannot compare %v with %v", obj, best)
+		}
+		if check(c) {
+			best = obj
+		}
+	}
+	return best, nil
+}
+
+func minFn(objs []Any) (Any, error) {
+	return extremum(objs, func(c int) bool { return c < 0 })
+}
+
+func maxFn(objs []Any) (Any, error) {
+	return extremum(objs, func(c int) bool { return c > 0 })
+}
+
+// dotFn is the dot product of two lists of equal length
+func dotFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	x, err := getList(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	y, err := getList(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("lists of different lengths (%d and %d)", len(x), len(y))
+	}
+
+	var total Any = Int(0)
+	for i := range x {
+		prod, err := mulOp.apply(x[i], y[i])
+		if err != nil {
+			return nil, err
+		}
+		total, err = addOp.apply(total, prod)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// linspaceFn returns n evenly spaced floats from start to stop, inclusive
+func linspaceFn(objs []Any) (Any, error) {
+	if len(objs) != 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	start, err := floatValue(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	stop, err := floatValue(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	n, ok := objs[2].(Int)
+	if !ok || n < 0 {
+		return nil, &ErrWrongType{objs[2]}
+	}
+
+	result := make(List, n)
+	switch n {
+	case 0:
+		return result, nil
+	case 1:
+		result[0] = start
+		return result, nil
+	}
+	step := (stop - start) / Float(n-1)
+	for i := range result {
+		result[i] = start + Float(i)*step
+	}
+	// avoid the rounding errors at the end
+	result[n-1] = stop
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestStatistics(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(sum '())`, Int(0)},
+		{`(sum '(1 2 3))`, Int(6)},
+		{`(sum '(1 2.5))`, Float(3.5)},
+		{`(sum '(1/2 1/3))`, big.NewRat(5, 6)},
+		{`(sum (generator (fn () (yield 1) (yield 2))))`, Int(3)},
+		{`(cumsum '(1 2 3 4))`, List{Int(1), Int(3), Int(6), Int(10)}},
+		{`(cumsum '())`, List{}},
+		{`(mean '(1 2 3 4))`, Float(2.5)},
+		{`(median '(5 1 3))`, Float(3)},
+		{`(median '(4 1 3 2))`, Float(2.5)},
+		{`(quantile '(1 2 3 4 5) 0)`, Float(1)},
+		{`(quantile '(1 2 3 4 5) 1)`, Float(5)},
+		{`(quantile '(1 2 3 4 5) 0.1)`, Float(1.4)},
+		{`(quantile '(5 4 3 2 1) '(0.25 0.75))`, List{Float(2), Float(4)}},
+		{`(quantile '(7) 0.3)`, Float(7)},
+		{`(variance '(2 4 4 4 5 5 7 9))`, Float(32.0 / 7)},
+		{`(stdev '(1 3))`, Float(math.Sqrt2)},
+		{`(min '(3 1 2))`, Int(1)},
+		{`(max '(3 1 2))`, Int(3)},
+		{`(min 3 1.5 2)`, Float(1.5)},
+		{`(max 3 1/2 2)`, Int(3)},
+		{`(min 5)`, Int(5)},
+		{`(str (max (list (duration 1) (duration 60))))`, String("1m0s")},
+		{`(dot '(1 2 3) '(4 5 6))`, Int(32)},
+		{`(dot '() '())`, Int(0)},
+		{`(linspace 0 1 5)`, List{Float(0), Float(0.25), Float(0.5), Float(0.75), Float(1)}},
+		{`(linspace 1 0 3)`, List{Float(1), Float(0.5), Float(0)}},
+		{`(linspace 2 3 1)`, List{Float(2)}},
+		{`(linspace 2 3 0)`, List{}},
+		{`(last (linspace 0 0.3 4))`, Float(0.3)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestVectorizedMath(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(sqrt '(1 4 9))`, List{Float(1), Float(2), Float(3)}},
+		{`(floor '(1.5 -1.5))`, List{Float(1), Float(-2)}},
+		{`(sqrt '())`, List{}},
+		{`(abs '(-1 2 -3/2))`, List{Int(1), Int(2), big.NewRat(3, 2)}},
+		{`(exp (list 0 (complex 0 0)))`, List{Float(1), Complex(1)}},
+		{`(real (list (complex 1 2) 3))`, List{Float(1), Int(3)}},
+		{`(sqrt 4)`, Float(2)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestStatisticsErrors(t *testing.T) {
+	var testCases = []string{
+		`(sum 1)`,
+		`(sum '(1 "a"))`,
+		`(mean '())`,
+		`(mean '(1 "a"))`,
+		`(median '())`,
+		`(variance '(1))`,
+		`(stdev '())`,
+		`(quantile '(1 2) 1.5)`,
+		`(quantile '(1 2) -0.1)`,
+		`(quantile '(1 2))`,
+		`(min '())`,
+		`(min)`,
+		`(max 1 "a")`,
+		`(max "a" 1)`,
+		`(min (complex 1 1) 2)`,
+		`(dot '(1 2) '(1))`,
+		`(dot '(1 "a") '(1 2))`,
+		`(linspace 0 1 -1)`,
+		`(linspace 0 1 2.5)`,
+		`(sqrt '(1 "a"))`,
+	}
+
+	runErrorTests(t, testCases)
+}
@@ -0,0 +1,256 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// All the indexes and lengths are counted in characters (runes), not bytes.
+
+// maxStringSize is the limit for the size, in bytes, of the created strings
+const maxStringSize = 1 << 28
+
+func getIndex(obj Any) (int, error) {
+	i, ok := obj.(Int)
+	if !ok {
+		return 0, fmt.Errorf("%v (%T) is not an int", obj, obj)
+	}
+	return i, nil
+}
+
+func substringFn(objs []Any) (Any, error) {
+	if len(objs) < 2 || len(objs) > 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	str, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(str)
+
+	start, err := getIndex(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	end := len(runes)
+	if len(objs) == 3 {
+		end, err = getIndex(objs[2])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if start < 0 || end > len(runes) || start > end {
+		return nil, fmt.Errorf("substring [%d:%d] is out of bounds for string of length %d", start, end, len(runes))
+	}
+	return newText(string(runes[start:end])), nil
+}
+
+func splitFn(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	strs, err := getTexts(objs)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	if len(strs) == 1 {
+		parts = strings.Fields(strs[0])
+	} else {
+		parts = strings.Split(strs[0], strs[1])
+	}
+
+	out := List{}
+	for _, s := range parts {
+		out = append(out, newText(s))
+	}
+	return out, nil
+}
+
+func joinFn(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	l, ok := objs[0].(List)
+	if !ok {
+		return nil, &ErrWrongType{objs[0]}
+	}
+	var sep String
+	if len(objs) == 2 {
+		s, err := getText(objs[1])
+		if err != nil {
+			return nil, err
+		}
+		sep = newText(s)
+	}
+	if len(l) == 0 {
+		return String(""), nil
+	}
+	str, err := toString(l, sep)
+	return String(str), err
+}
+
+// trimFunction trims whitespace, or the characters from the optional cutset
+type trimFunction struct {
+	space  func(string) string
+	cutset func(string, string) string
+}
+
+func (f *trimFunction) call(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	strs, err := getTexts(objs)
+	if err != nil {
+		return nil, err
+	}
+	if len(strs) == 1 {
+		return newText(f.space(strs[0])), nil
+	}
+	return newText(f.cutset(strs[0], strs[1])), nil
+}
+
+var (
+	trim = &trimFunction{
+		strings.TrimSpace,
+		strings.Trim,
+	}
+	trimLeft = &trimFunction{
+		func(s string) string { return strings.TrimLeftFunc(s, unicode.IsSpace) },
+		strings.TrimLeft,
+	}
+	trimRight = &trimFunction{
+		func(s string) string { return strings.TrimRightFunc(s, unicode.IsSpace) },
+		strings.TrimRight,
+	}
+)
+
+func replaceFn(objs []Any) (Any, error) {
+	if len(objs) != 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	strs, err := getTexts(objs)
+	if err != nil {
+		return nil, err
+	}
+	return newText(strings.ReplaceAll(strs[0], strs[1], strs[2])), nil
+}
+
+func stringPredicate(fn func(s, t string) bool) func([]Any) (Any, error) {
+	return func(objs []Any) (Any, error) {
+		if len(objs) != 2 {
+			return nil, &ErrNumArgs{len(objs)}
+		}
+		strs, err := getTexts(objs)
+		if err != nil {
+			return nil, err
+		}
+		return Bool(fn(strs[0], strs[1])), nil
+	}
+}
+
+func indexOfFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	strs, err := getTexts(objs)
+	if err != nil {
+		return nil, err
+	}
+	i := strings.Index(strs[0], strs[1])
+	if i < 0 {
+		return Int(-1), nil
+	}
+	return Int(utf8.RuneCountInString(strs[0][:i])), nil
+}
+
+func repeatStrFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	str, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	n, err := getIndex(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("negative repeat count: %d", n)
+	}
+	if n > 0 && len(str) > maxStringSize/n {
+		return nil, fmt.Errorf("repeating the string %d times is too big", n)
+	}
+	return newText(strings.Repeat(str, n)), nil
+}
+
+// padFunction pads the string to the width, using spaces
+// or the optional padding string
+func padFunction(left bool) func([]Any) (Any, error) {
+	return func(objs []Any) (Any, error) {
+		if len(objs) < 2 || len(objs) > 3 {
+			return nil, &ErrNumArgs{len(objs)}
+		}
+		str, err := getText(objs[0])
+		if err != nil {
+			return nil, err
+		}
+		width, err := getIndex(objs[1])
+		if err != nil {
+			return nil, err
+		}
+		if width < 0 {
+			return nil, fmt.Errorf("negative width: %d", width)
+		}
+		pad := " "
+		if len(objs) == 3 {
+			switch obj := objs[2].(type) {
+			case Char:
+				pad = string(obj)
+			default:
+				pad, err = getText(obj)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		if pad == "" {
+			return nil, fmt.Errorf("padding cannot be empty")
+		}
+
+		n := width - utf8.RuneCountInString(str)
+		if n <= 0 {
+			return newText(str), nil
+		}
+		if n > maxStringSize/utf8.UTFMax {
+			return nil, fmt.Errorf("padding to the width %d is too big", width)
+		}
+		// only the missing characters are created
+		runes := []rune(pad)
+		var padding strings.Builder
+		for i := 0; i < n; i++ {
+			padding.WriteRune(runes[i%len(runes)])
+		}
+		if left {
+			return newText(padding.String() + str), nil
+		}
+		return newText(str + padding.String()), nil
+	}
+}
+
+func reverseString(str String) (String, error) {
+	text, err := str.Unquote()
+	if err != nil {
+		return "", err
+	}
+	runes := []rune(text)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return newText(string(runes)), nil
+}
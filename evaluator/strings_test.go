@@ -0,0 +1,87 @@
+package evaluator
+
+import "testing"
+
+func TestStrings(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(substring "zażółć" 2)`, String("żółć")},
+		{`(substring "zażółć" 2 4)`, String("żó")},
+		{`(substring "abc" 3)`, String("")},
+		{`(split "a,b,,c" ",")`, List{String("a"), String("b"), String(""), String("c")}},
+		{"(split \"  hello \t world\n\")", List{String("hello"), String("world")}},
+		{`(split "" ",")`, List{String("")}},
+		{`(join '("a" "b" "c") ", ")`, String("a, b, c")},
+		{`(join '(1 #\b "c"))`, String("1bc")},
+		{`(join '() ",")`, String("")},
+		{"(trim \"  hello\n \")", String("hello")},
+		{`(trim "xxhelloxy" "xy")`, String("hello")},
+		{`(trim-left "  hello  ")`, String("hello  ")},
+		{`(trim-right "  hello  ")`, String("  hello")},
+		{`(trim-left "xxhello" "x")`, String("hello")},
+		{`(upper "zażółć")`, String("ZAŻÓŁĆ")},
+		{`(lower "ZAŻÓŁĆ")`, String("zażółć")},
+		{`(replace "a-b-c" "-" "+")`, String("a+b+c")},
+		{`(starts-with? "hello" "he")`, Bool(true)},
+		{`(starts-with? "hello" "lo")`, Bool(false)},
+		{`(ends-with? "hello" "lo")`, Bool(true)},
+		{`(index-of "zażółć" "ół")`, Int(3)},
+		{`(index-of "hello" "x")`, Int(-1)},
+		{`(string-length "zażółć")`, Int(6)},
+		{`(string-length "")`, Int(0)},
+		{`(repeat-str "ab" 3)`, String("ababab")},
+		{`(repeat-str "ab" 0)`, String("")},
+		{`(pad-left "42" 5)`, String("   42")},
+		{`(pad-left "42" 5 #\0)`, String("00042")},
+		{`(pad-right "ż" 3 "-")`, String("ż--")},
+		{`(pad-right "hello" 3)`, String("hello")},
+		{`(pad-left "x" 4 "ab")`, String("abax")},
+		{`(reverse "zażółć")`, String("ćłóżaz")},
+		{`(reverse "")`, String("")},
+		// escape sequences are single characters
+		{`(upper "a\tb")`, String(`A\tB`)},
+		{`(lower "A\nB")`, String(`a\nb`)},
+		{`(reverse "a\nb")`, String(`b\na`)},
+		{`(string-length "a\nb")`, Int(3)},
+		{`(string-length "\u0105\"")`, Int(2)},
+		{`(split "a\tb")`, List{String("a"), String("b")}},
+		{`(split "a\nb\nc" "\n")`, List{String("a"), String("b"), String("c")}},
+		{`(substring "a\nbc" 1 3)`, String(`\nb`)},
+		{`(trim "\t hello\n")`, String("hello")},
+		{`(replace "a\nb" "n" "x")`, String(`a\nb`)},
+		{`(index-of "a\nb" "b")`, Int(2)},
+		{`(pad-left "\n" 3 "-")`, String(`--\n`)},
+		{`(= (nth "a\tb" 1) #\tab)`, Bool(true)},
+		{`(= (chars "a\n") (list #\a #\newline))`, Bool(true)},
+		{`(= (upper "a\tb") "A\tB")`, Bool(true)},
+		// the strings created from the other values are escaped too
+		{`(upper (str #\" 'a))`, String(`\"A`)},
+		{`(string-length (str #\newline '("a")))`, Int(6)},
+		{`(= (str #\tab) "\t" "\x09")`, Bool(true)},
+		{`(split (str '("a" "b")))`, List{String(`(\"a\"`), String(`\"b\")`)}},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestStringsErrors(t *testing.T) {
+	var testCases = []string{
+		`(substring "abc" 2 1)`,
+		`(substring "abc" 0 4)`,
+		`(substring "abc" -1)`,
+		`(split 42)`,
+		`(join "abc")`,
+		`(upper 'abc)`,
+		`(repeat-str "a" -1)`,
+		`(pad-left "a" 3 "")`,
+		`(upper "\q")`,
+		`(pad-left "a" -3)`,
+		`(pad-right "a" -1 "-")`,
+		`(repeat-str "ab" 9223372036854775807)`,
+		`(repeat-str "ab" 4611686018427387904)`,
+		`(pad-left "ab" 9223372036854775807)`,
+		`(pad-right "ab" 1000000000 "xy")`,
+		`(index-of "abc")`,
+	}
+
+	runErrorTests(t, testCases)
+}
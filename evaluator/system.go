@@ -0,0 +1,114 @@
+package evaluator
+
+import (
+	"os"
+	"strings"
+)
+
+// osBuildins require the AllowOS capability
+var osBuildins = map[Symbol]Any{
+	"getenv": &multiArgFunction{
+		// (getenv <name> [<default>])
+		getenvFn,
+	},
+	"setenv": &multiArgFunction{
+		// (setenv <name> <value>)
+		setenvFn,
+	},
+	"unsetenv": &singleArgFunction{
+		// (unsetenv <name>)
+		func(obj Any) (Any, error) {
+			name, err := getText(obj)
+			if err != nil {
+				return nil, err
+			}
+			return nil, os.Unsetenv(name)
+		},
+	},
+	"environ": &noArgFunction{
+		// (environ)
+		environFn,
+	},
+	"hostname": &noArgFunction{
+		// (hostname)
+		func() (Any, error) {
+			name, err := os.Hostname()
+			return newText(name), err
+		},
+	},
+	"pid": &noArgFunction{
+		// (pid)
+		func() (Any, error) {
+			return Int(os.Getpid()), nil
+		},
+	},
+	"cwd": &noArgFunction{
+		// (cwd)
+		func() (Any, error) {
+			dir, err := os.Getwd()
+			return newText(dir), err
+		},
+	},
+	"chdir": &singleArgFunction{
+		// (chdir <path>)
+		func(obj Any) (Any, error) {
+			dir, err := getText(obj)
+			if err != nil {
+				return nil, err
+			}
+			return nil, os.Chdir(dir)
+		},
+	},
+	"home-dir": &noArgFunction{
+		// (home-dir)
+		func() (Any, error) {
+			dir, err := os.UserHomeDir()
+			return newText(dir), err
+		},
+	},
+}
+
+// getenvFn returns the value of the environment variable,
+// or the default (nil) when it is not set
+func getenvFn(objs []Any) (Any, error) {
+	if len(objs) < 1 || len(objs) > 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	if val, ok := os.LookupEnv(name); ok {
+		return newText(val), nil
+	}
+	if len(objs) == 2 {
+		return objs[1], nil
+	}
+	return nil, nil
+}
+
+func setenvFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	name, err := getText(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	val, err := toText(objs[1:], "")
+	if err != nil {
+		return nil, err
+	}
+	return nil, os.Setenv(name, val)
+}
+
+// environFn returns the environment variables as a map
+func environFn() (Any, error) {
+	env := make(Map)
+	for _, kv := range os.Environ() {
+		if i := strings.Index(kv, "="); i > 0 {
+			env[newText(kv[:i])] = newText(kv[i+1:])
+		}
+	}
+	return env, nil
+}
@@ -0,0 +1,73 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSystem(t *testing.T) {
+	os.Setenv("GOL_TEST_VAR", "hello")
+	defer os.Unsetenv("GOL_TEST_VAR")
+	os.Unsetenv("GOL_TEST_UNSET")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testCases = []evalTestCase{
+		{`(getenv "GOL_TEST_VAR")`, String("hello")},
+		{`(getenv "GOL_TEST_UNSET")`, nil},
+		{`(getenv "GOL_TEST_UNSET" "default")`, String("default")},
+		{`(get (environ) "GOL_TEST_VAR")`, String("hello")},
+		{`(setenv "GOL_TEST_VAR" 42) (getenv "GOL_TEST_VAR")`, String("42")},
+		{`(unsetenv "GOL_TEST_VAR") (getenv "GOL_TEST_VAR")`, nil},
+		{`(setenv "GOL_TEST_VAR" "say \"hi\"\\") (upper (getenv "GOL_TEST_VAR"))`, String(`SAY \"HI\"\\`)},
+		{`(setenv "GOL_TEST_VAR" "a\tb") (= (get (environ) "GOL_TEST_VAR") "a\tb")`, Bool(true)},
+		{`(int? (pid))`, Bool(true)},
+		{`(= (pid) (pid))`, Bool(true)},
+		{`(str? (hostname))`, Bool(true)},
+		{`(str? (home-dir))`, Bool(true)},
+		{`(chdir "` + filepath.ToSlash(dir) + `") (cwd)`, String(dir)},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestCapabilities(t *testing.T) {
+	var testCases = []string{
+		`(getenv "HOME")`,
+		`(setenv "GOL_TEST_VAR" "x")`,
+		`(environ)`,
+		`(pid)`,
+		`(cwd)`,
+	}
+
+	for _, input := range testCases {
+		e := NewEvaluatorWith(0)
+		result, err := e.EvalString(input)
+		if err == nil || !strings.Contains(err.Error(), "is disabled") {
+			t.Errorf("for %s expected the function to be disabled, got: %v, %v", input, result, err)
+		}
+
+		e = NewEvaluatorWith(AllowOS)
+		if _, err := e.EvalString(input); err != nil {
+			t.Errorf("for %s unexpected error: %s", input, err)
+		}
+	}
+
+	// the sandbox does not limit the other builtins
+	e := NewEvaluatorWith(0)
+	if _, err := e.EvalString(`(+ 1 2)`); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	os.Unsetenv("GOL_TEST_VAR")
+}
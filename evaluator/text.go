@@ -0,0 +1,64 @@
+package evaluator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Strings hold the escaped text, as in the string literals, so "a\nb" holds
+// the backslash followed by n. The functions reading the strings use getText
+// that interprets the escape sequences, and the functions creating them,
+// e.g. from the files, the processes, or the environment, use newText,
+// so the strings coming from all the sources work the same way.
+
+// getText returns the unquoted text of the string
+func getText(obj Any) (string, error) {
+	str, ok := obj.(String)
+	if !ok {
+		return "", fmt.Errorf("%v (%T) is not a string", obj, obj)
+	}
+	text, err := str.Unquote()
+	return text.Raw(), err
+}
+
+func getTexts(objs []Any) ([]string, error) {
+	var out []string
+	for _, obj := range objs {
+		text, err := getText(obj)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, text)
+	}
+	return out, nil
+}
+
+// newText creates the string from the text, escaping it
+func newText(text string) String {
+	return String(text).Quote()
+}
+
+// toText joins the unquoted strings, characters, and representations
+// of other values, it is used when writing them to the outside world
+func toText(objs []Any, sep string) (string, error) {
+	if len(objs) == 0 {
+		return "", &ErrNumArgs{len(objs)}
+	}
+
+	var out []string
+	for _, obj := range objs {
+		switch obj := obj.(type) {
+		case String:
+			text, err := obj.Unquote()
+			if err != nil {
+				return "", err
+			}
+			out = append(out, text.Raw())
+		case Char:
+			out = append(out, string(obj))
+		default:
+			out = append(out, fmt.Sprintf("%v", obj))
+		}
+	}
+	return strings.Join(out, sep), nil
+}
@@ -0,0 +1,212 @@
+package evaluator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	// so that time zones are available even if the system has no tz database
+	_ "time/tzdata"
+)
+
+// timeLayouts are the names of the common layouts, other layouts
+// are given as strings using Go's reference time Mon Jan 2 15:04:05 MST 2006
+var timeLayouts = map[Symbol]string{
+	":rfc3339":      time.RFC3339,
+	":rfc3339-nano": time.RFC3339Nano,
+	":rfc1123":      time.RFC1123,
+	":rfc822":       time.RFC822,
+	":kitchen":      time.Kitchen,
+	":date-time":    "2006-01-02 15:04:05",
+	":date":         "2006-01-02",
+	":time":         "15:04:05",
+}
+
+func getTime(obj Any) (Time, error) {
+	t, ok := obj.(Time)
+	if !ok {
+		return Time{}, fmt.Errorf("%v (%T) is not a time", obj, obj)
+	}
+	return t, nil
+}
+
+// getDuration accepts durations, or numbers of seconds
+func getDuration(obj Any) (Duration, error) {
+	switch obj := obj.(type) {
+	case Duration:
+		return obj, nil
+	default:
+		if !isNumber(obj) {
+			return 0, fmt.Errorf("%v (%T) is not a duration", obj, obj)
+		}
+		sec, err := floatValue(obj)
+		if err != nil {
+			return 0, err
+		}
+		return Duration(math.Round(sec * float64(time.Second))), nil
+	}
+}
+
+func getLayout(obj Any) (string, error) {
+	switch obj := obj.(type) {
+	case String:
+		return getText(obj)
+	case Symbol:
+		if layout, ok := timeLayouts[obj]; ok {
+			return layout, nil
+		}
+	}
+	return "", fmt.Errorf("%v (%T) is not a valid time layout", obj, obj)
+}
+
+func getLocation(obj Any) (*time.Location, error) {
+	name, err := getText(obj)
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocation(name)
+}
+
+// unixTimeFn returns the Unix time, in seconds, for now or for the time
+func unixTimeFn(objs []Any) (Any, error) {
+	switch len(objs) {
+	case 0:
+		return Int(time.Now().Unix()), nil
+	case 1:
+		t, err := getTime(objs[0])
+		if err != nil {
+			return nil, err
+		}
+		return Int(t.Unix()), nil
+	default:
+		return nil, &ErrNumArgs{len(objs)}
+	}
+}
+
+func fromUnixTimeFn(obj Any) (Any, error) {
+	sec, err := getDuration(obj)
+	if err != nil {
+		return nil, err
+	}
+	return Time{Time: time.Unix(0, int64(sec))}, nil
+}
+
+// parseTimeFn parses the time in UTC, or in the time zone given as the third argument
+func parseTimeFn(objs []Any) (Any, error) {
+	if len(objs) < 2 || len(objs) > 3 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	layout, err := getLayout(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	str, err := getText(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	loc := time.UTC
+	if len(objs) == 3 {
+		if loc, err = getLocation(objs[2]); err != nil {
+			return nil, err
+		}
+	}
+	t, err := time.ParseInLocation(layout, str, loc)
+	if err != nil {
+		return nil, err
+	}
+	return Time{Time: t}, nil
+}
+
+func formatTimeFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	t, err := getTime(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	layout, err := getLayout(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	return newText(t.Format(layout)), nil
+}
+
+// durationFn parses the duration like "1h30m", or converts the number of seconds
+func durationFn(obj Any) (Any, error) {
+	if _, ok := obj.(String); ok {
+		str, err := getText(obj)
+		if err != nil {
+			return nil, err
+		}
+		d, err := time.ParseDuration(str)
+		return Duration(d), err
+	}
+	return getDuration(obj)
+}
+
+// addDurationFn adds the durations to the time or duration
+func addDurationFn(objs []Any) (Any, error) {
+	if len(objs) < 1 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+
+	var total Duration
+	for _, obj := range objs[1:] {
+		d, err := getDuration(obj)
+		if err != nil {
+			return nil, err
+		}
+		total += d
+	}
+
+	switch obj := objs[0].(type) {
+	case Time:
+		return Time{Time: obj.Add(time.Duration(total))}, nil
+	default:
+		d, err := getDuration(obj)
+		if err != nil {
+			return nil, err
+		}
+		return d + total, nil
+	}
+}
+
+func timeDiffFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	t1, err := getTime(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	t2, err := getTime(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	return Duration(t1.Sub(t2.Time)), nil
+}
+
+func inTimeZoneFn(objs []Any) (Any, error) {
+	if len(objs) != 2 {
+		return nil, &ErrNumArgs{len(objs)}
+	}
+	t, err := getTime(objs[0])
+	if err != nil {
+		return nil, err
+	}
+	loc, err := getLocation(objs[1])
+	if err != nil {
+		return nil, err
+	}
+	return Time{Time: t.In(loc)}, nil
+}
+
+func sleepFn(obj Any) (Any, error) {
+	d, err := getDuration(obj)
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(time.Duration(d))
+	return nil, nil
+}
@@ -0,0 +1,61 @@
+package evaluator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	var testCases = []evalTestCase{
+		{`(time? (now))`, Bool(true)},
+		{`(time? 42)`, Bool(false)},
+		{`(int? (unix-time))`, Bool(true)},
+		{`(unix-time (parse-time :rfc3339 "2021-03-04T05:06:07Z"))`, Int(1614834367)},
+		{`(format-time (from-unix-time 1614834367) :rfc3339)`, String(time.Unix(1614834367, 0).Format(time.RFC3339))},
+		{`(= (from-unix-time 1614834367) (parse-time :rfc3339 "2021-03-04T05:06:07Z"))`, Bool(true)},
+		{`(format-time (parse-time "02/01/2006" "04/03/2021") :date)`, String("2021-03-04")},
+		{`(format-time (parse-time :date-time "2021-03-04 05:06:07") "Jan 2, 2006 at 3:04pm (MST)")`, String("Mar 4, 2021 at 5:06am (UTC)")},
+		{`(str (parse-time :date "2021-03-04" "Europe/Warsaw"))`, String("2021-03-04T00:00:00+01:00")},
+		{`(str (in-tz (parse-time :date-time "2021-07-01 12:00:00") "America/New_York"))`, String("2021-07-01T08:00:00-04:00")},
+		{`(duration "1h30m")`, Duration(90 * time.Minute)},
+		{`(duration 1.5)`, Duration(1500 * time.Millisecond)},
+		{`(str (duration 90))`, String("1m30s")},
+		{`(duration? (duration 1))`, Bool(true)},
+		{`(duration->seconds (duration "1m30s"))`, Float(90)},
+		{`(+duration (duration "1h") (duration "30m") 15)`, Duration(time.Hour + 30*time.Minute + 15*time.Second)},
+		{`(format-time (+duration (parse-time :date "2021-03-04") (duration "36h")) :date-time)`, String("2021-03-05 12:00:00")},
+		{`(time-diff (parse-time :date "2021-03-05") (parse-time :date "2021-03-04"))`, Duration(24 * time.Hour)},
+		{`(time-diff (parse-time :date "2021-03-04") (parse-time :date "2021-03-05"))`, Duration(-24 * time.Hour)},
+		{`(< (parse-time :date "2021-03-04") (parse-time :date "2021-03-05") (now))`, Bool(true)},
+		{`(> (parse-time :date "2021-03-04") (parse-time :date "2021-03-05"))`, Bool(false)},
+		{`(< (duration 1) (duration "2s"))`, Bool(true)},
+		{`(> (duration "1h") (duration "59m") (duration 0))`, Bool(true)},
+		{`(= (duration 60) (duration "1m"))`, Bool(true)},
+		{`(let (start (now)) (sleep 0.01) (> (time-diff (now) start) (duration "10ms")))`, Bool(true)},
+		{`(sleep (duration "1ms"))`, nil},
+	}
+
+	runTests(testCases, t)
+}
+
+func TestTimeErrors(t *testing.T) {
+	var testCases = []string{
+		`(parse-time :rfc3339 "2021-03-04")`,
+		`(parse-time :unknown "2021-03-04")`,
+		`(parse-time :date "2021-03-04" "Not/AZone")`,
+		`(format-time "2021-03-04" :date)`,
+		`(duration "1 hour")`,
+		`(duration "x")`,
+		`(duration '(1))`,
+		`(+duration (now) (now))`,
+		`(time-diff (now) 1)`,
+		`(< (now) 1)`,
+		`(< 1 (now))`,
+		`(> (duration 1) 1)`,
+		`(in-tz (now) "Not/AZone")`,
+		`(unix-time 1 2)`,
+		`(now 1)`,
+	}
+
+	runErrorTests(t, testCases)
+}
@@ -10,4 +10,17 @@ type (
 	String = types.String
 	Symbol = types.Symbol
 	List   = types.List
+
+	BigInt   = types.BigInt
+	Rational = types.Rational
+	Complex  = types.Complex
+	Char     = types.Char
+	Regex    = types.Regex
+	Map      = types.Map
+	Time     = types.Time
+	Duration = types.Duration
+	Matrix   = types.Matrix
+
+	Record     = types.Record
+	RecordType = types.RecordType
 )
@@ -1,7 +1,5 @@
 module github.com/twolodzko/gol
 
-go 1.16
+go 1.22
 
-require (
-	github.com/google/go-cmp v0.5.5 // indirect
-)
+require github.com/google/go-cmp v0.5.5
@@ -1,58 +1,165 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"log"
 	"os"
+	"strings"
 
 	"github.com/twolodzko/gol/evaluator"
 	"github.com/twolodzko/gol/parser"
 	"github.com/twolodzko/gol/repl"
+	"github.com/twolodzko/gol/types"
 )
 
 const prompt string = "> "
 
+const (
+	exitRuntimeError = 1
+	// the same as for invalid flags
+	exitUsageError = 2
+	exitParseError = 3
+)
+
+var (
+	exprFlag        = flag.String("e", "", "evaluate the expression")
+	interactiveFlag = flag.Bool("i", false, "start REPL after evaluating the script")
+	eachLineFlag    = flag.Bool("n", false, "evaluate the code for each line of the standard input, with line and line-no bound")
+	printLineFlag   = flag.Bool("p", false, "like -n, but print the result of each evaluation")
+)
+
 func main() {
-	if len(os.Args) == 2 {
-		if os.Args[1] == "-h" || os.Args[1] == "--help" {
-			printHelp()
-			return
-		}
-		evalScript()
+	flag.Usage = printHelp
+	flag.Parse()
+
+	code, args, err := readCode()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(exitUsageError)
+	}
+
+	e := evaluator.NewEvaluator()
+	e.Set("*args*", toList(args))
+
+	switch {
+	case code == "":
+		startRepl(e)
 		return
+	case *eachLineFlag || *printLineFlag:
+		evalLines(e, code, *printLineFlag)
+	default:
+		evalScript(e, code)
 	}
 
-	startRepl()
+	if *interactiveFlag {
+		startRepl(e)
+	}
 }
 
 func printHelp() {
-	fmt.Printf("%s [script]\n", os.Args[0])
+	fmt.Printf("%s [flags] [script | -] [--] [args...]\n", os.Args[0])
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Printf("  %s             start REPL\n", os.Args[0])
 	fmt.Printf("  %s script.lsp  evaluate script.lsp\n", os.Args[0])
+	fmt.Printf("  %s -           evaluate the script read from the standard input\n", os.Args[0])
 	fmt.Printf("  %s -h,--help   display help\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("The arguments following the script, or the -- separator, are available as *args*.")
+	fmt.Println()
+	fmt.Println("Flags:")
+	flag.PrintDefaults()
+	fmt.Println()
+	fmt.Println("Example:")
+	fmt.Printf("  cat file.txt | %s -p -e '(upper line)'\n", os.Args[0])
 }
 
-func evalScript() {
-	code, err := parser.ReadFile(os.Args[1])
-	if err != nil {
-		log.Panic(err)
+// readCode returns the code to evaluate and the arguments passed to the script
+func readCode() (string, []string, error) {
+	args := flag.Args()
+	if *exprFlag != "" {
+		return *exprFlag, args, nil
+	}
+	if len(args) == 0 {
+		return "", nil, nil
 	}
 
-	e := evaluator.NewEvaluator()
-	objs, err := e.EvalString(code)
-	if err != nil {
-		log.Panic(err)
+	script, args := args[0], args[1:]
+	if len(args) > 0 && args[0] == "--" {
+		args = args[1:]
+	}
+
+	if script == "-" {
+		code, err := io.ReadAll(evaluator.Stdin())
+		return string(code), args, err
+	}
+	code, err := parser.ReadFile(script)
+	return code, args, err
+}
+
+func toList(args []string) types.List {
+	l := types.List{}
+	for _, arg := range args {
+		l = append(l, types.String(arg).Quote())
 	}
+	return l
+}
+
+func evalScript(e *evaluator.Evaluator, code string) {
+	objs, err := e.EvalString(code)
+	exitOnError(err)
 	if len(objs) > 0 {
 		fmt.Printf("%v\n", objs[len(objs)-1])
 	}
 }
 
-func startRepl() {
-	repl := repl.NewRepl(os.Stdin)
+// evalLines evaluates the code for each line of the standard input,
+// the line and line-no variables are bound to the line and its number
+func evalLines(e *evaluator.Evaluator, code string, autoprint bool) {
+	exprs, err := parser.Parse(strings.NewReader(code))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: parsing error: %s\n", err)
+		os.Exit(exitParseError)
+	}
+
+	for i := 1; ; i++ {
+		line, ok, err := evaluator.ReadLine()
+		exitOnError(err)
+		if !ok {
+			return
+		}
+
+		// escaped like the string literals
+		e.Set("line", types.String(line).Quote())
+		e.Set("line-no", i)
+
+		objs, err := e.Eval(exprs)
+		exitOnError(err)
+		if autoprint && len(objs) > 0 {
+			printValue(objs[len(objs)-1])
+		}
+	}
+}
+
+// printValue prints the value like println, the nil values are skipped
+func printValue(obj types.Any) {
+	switch obj := obj.(type) {
+	case nil:
+	case types.String:
+		text, err := obj.Unquote()
+		exitOnError(err)
+		fmt.Println(text.Raw())
+	case types.Char:
+		fmt.Println(string(obj))
+	default:
+		fmt.Println(obj)
+	}
+}
+
+func startRepl(e *evaluator.Evaluator) {
+	repl := repl.NewReplWithEvaluator(evaluator.Stdin(), e)
 
 	fmt.Println("Press ^C to exit.")
 	fmt.Println()
@@ -62,6 +169,14 @@ func startRepl() {
 
 		objs, err := repl.Repl()
 
+		if err == io.EOF {
+			fmt.Println()
+			return
+		}
+		var exit *evaluator.ErrExit
+		if errors.As(err, &exit) {
+			os.Exit(exit.Code)
+		}
 		if err != nil {
 			print(fmt.Sprintf("ERROR: %s", err))
 			continue
@@ -73,6 +188,29 @@ func startRepl() {
 	}
 }
 
+// exitOnError prints the error to stderr and terminates the program
+// with the exit status depending on the kind of the error
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+
+	var (
+		exit  *evaluator.ErrExit
+		parse *evaluator.ErrParse
+	)
+	switch {
+	case errors.As(err, &exit):
+		os.Exit(exit.Code)
+	case errors.As(err, &parse):
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(exitParseError)
+	default:
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(exitRuntimeError)
+	}
+}
+
 func print(msg string) {
 	io.WriteString(os.Stdout, fmt.Sprintf("%s\n", msg))
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestMain runs gol instead of the tests when GOL_RUN_MAIN is set,
+// so the tests can run it as a separate process
+func TestMain(m *testing.M) {
+	if os.Getenv("GOL_RUN_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runGol runs gol with the flags, the expression, and the standard input
+func runGol(t *testing.T, flags, expr, input string) string {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], flags, "-e", expr)
+	cmd.Env = append(os.Environ(), "GOL_RUN_MAIN=1")
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("gol %s -e %s failed: %s\n%s", flags, expr, err, out)
+	}
+	return string(out)
+}
+
+func TestEachLine(t *testing.T) {
+	var testCases = []struct {
+		flags, expr, input, expected string
+	}{
+		{"-p", "(upper line)", "say \"hi\"\nback\\slash\n", "SAY \"HI\"\nBACK\\SLASH\n"},
+		{"-n", "(println line-no (upper line))", "a\\nb \"c\"\n", "1 A\\NB \"C\"\n"},
+		{"-p", "(string-length line)", "\"\\\"\n", "3\n"},
+		{"-p", "(split line \"\\\"\")", "a\"b\n", "(\"a\" \"b\")\n"},
+	}
+
+	for _, tc := range testCases {
+		result := runGol(t, tc.flags, tc.expr, tc.input)
+		if result != tc.expected {
+			t.Errorf("for %s -e %s with input %q expected %q, got %q", tc.flags, tc.expr, tc.input, tc.expected, result)
+		}
+	}
+}
@@ -11,10 +11,15 @@ import (
 )
 
 var (
-	intRegex   = regexp.MustCompile(`^[+-]?\d+$`)
+	intRegex   = regexp.MustCompile(`^[+-]?(?:0[xX][_\da-fA-F]+|0[bB][_01]+|0[oO][_0-7]+|\d[_\d]*)$`)
 	floatRegex = regexp.MustCompile(`^[+-]?\d*\.?(?:\d+[eE]?[+-]?)?\d+$`)
+	ratioRegex = regexp.MustCompile(`^[+-]?\d+/\d+$`)
+	// real part is optional: 3+4i, -2.5i
+	complexRegex = regexp.MustCompile(`^(?:[+-]?` + unsignedFloat + `[+-]|[+-]?)` + unsignedFloat + `i$`)
 )
 
+const unsignedFloat = `(?:\d+\.?\d*|\.\d+)(?:[eE][+-]?\d+)?`
+
 type Lexer struct {
 	*CodeReader
 }
@@ -66,6 +71,10 @@ func (l *Lexer) nextToken() (token.Token, error) {
 	case '"':
 		str, err = l.readString()
 		return token.New(str, token.STRING), err
+	case '#':
+		return l.readDispatch()
+	case '$':
+		return l.readInterpolated()
 	default:
 		str, err = l.readWord()
 		return token.New(str, guessType(str)), err
@@ -80,6 +89,14 @@ func IsFloat(str string) bool {
 	return floatRegex.MatchString(str)
 }
 
+func IsRatio(str string) bool {
+	return ratioRegex.MatchString(str)
+}
+
+func IsComplex(str string) bool {
+	return complexRegex.MatchString(str)
+}
+
 func guessType(str string) string {
 	switch {
 	case str == "true", str == "false":
@@ -90,6 +107,10 @@ func guessType(str string) string {
 		return token.INT
 	case IsFloat(str):
 		return token.FLOAT
+	case IsRatio(str):
+		return token.RATIO
+	case IsComplex(str):
+		return token.CMPLX
 	default:
 		return token.SYMBOL
 	}
@@ -176,6 +197,78 @@ func (l *Lexer) readWord() (string, error) {
 	return string(runes), err
 }
 
+// readDispatch reads the special syntax starting with #
+func (l *Lexer) readDispatch() (token.Token, error) {
+	if err := l.NextRune(); err != nil {
+		if err == io.EOF {
+			err = nil
+		}
+		return token.New("#", token.SYMBOL), err
+	}
+
+	switch {
+	case l.Head == '\\':
+		str, err := l.readChar()
+		return token.New(str, token.CHAR), err
+	case l.Head == '"':
+		str, err := l.readString()
+		return token.New(str, token.REGEX), err
+	case IsWordBoundary(l.Head):
+		return token.New("#", token.SYMBOL), l.UnreadRune()
+	default:
+		str, err := l.readWord()
+		return token.New("#"+str, token.SYMBOL), err
+	}
+}
+
+// readInterpolated reads the interpolated string $"...", or a symbol starting with $
+func (l *Lexer) readInterpolated() (token.Token, error) {
+	if err := l.NextRune(); err != nil {
+		if err == io.EOF {
+			err = nil
+		}
+		return token.New("$", token.SYMBOL), err
+	}
+
+	switch {
+	case l.Head == '"':
+		str, err := l.readString()
+		return token.New(str, token.INTERP), err
+	case IsWordBoundary(l.Head):
+		return token.New("$", token.SYMBOL), l.UnreadRune()
+	default:
+		str, err := l.readWord()
+		return token.New("$"+str, guessType("$"+str)), err
+	}
+}
+
+// readChar reads the character literal after #\, it is either
+// a single character (including the ones that are word boundaries) or a name
+func (l *Lexer) readChar() (string, error) {
+	r, _, err := l.ReadRune()
+	if err != nil {
+		if err == io.EOF {
+			err = errors.New("missing character after #\\")
+		}
+		return "", err
+	}
+
+	runes := []rune{r}
+	for {
+		r, _, err := l.ReadRune()
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return string(runes), err
+		}
+		if IsWordBoundary(r) || IsCommentStart(r) {
+			return string(runes), l.UnreadRune()
+		}
+		runes = append(runes, r)
+	}
+}
+
 func IsWordBoundary(r rune) bool {
 	return unicode.IsSpace(r) || r == '(' || r == ')'
 }
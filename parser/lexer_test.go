@@ -102,6 +102,57 @@ func TestLexer(t *testing.T) {
 				{Literal: "0", Type: token.INT},
 			},
 		},
+		{
+			"1/3 -22/7 / int/ 1/x",
+			[]token.Token{
+				{Literal: "1/3", Type: token.RATIO},
+				{Literal: "-22/7", Type: token.RATIO},
+				{Literal: "/", Type: token.SYMBOL},
+				{Literal: "int/", Type: token.SYMBOL},
+				{Literal: "1/x", Type: token.SYMBOL},
+			},
+		},
+		{
+			"3+4i -1.5-2e3i 4i -.5i i 3+i",
+			[]token.Token{
+				{Literal: "3+4i", Type: token.CMPLX},
+				{Literal: "-1.5-2e3i", Type: token.CMPLX},
+				{Literal: "4i", Type: token.CMPLX},
+				{Literal: "-.5i", Type: token.CMPLX},
+				{Literal: "i", Type: token.SYMBOL},
+				{Literal: "3+i", Type: token.SYMBOL},
+			},
+		},
+		{
+			`(#\a #\( #\) #\; #\newline #\λ # #foo)`,
+			[]token.Token{
+				{Literal: "(", Type: token.LPAREN},
+				{Literal: "a", Type: token.CHAR},
+				{Literal: "(", Type: token.CHAR},
+				{Literal: ")", Type: token.CHAR},
+				{Literal: ";", Type: token.CHAR},
+				{Literal: "newline", Type: token.CHAR},
+				{Literal: "λ", Type: token.CHAR},
+				{Literal: "#", Type: token.SYMBOL},
+				{Literal: "#foo", Type: token.SYMBOL},
+				{Literal: ")", Type: token.RPAREN},
+			},
+		},
+		{
+			`#"\d+(\.\d+)?" #"a\"b"`,
+			[]token.Token{
+				{Literal: `\d+(\.\d+)?`, Type: token.REGEX},
+				{Literal: `a\"b`, Type: token.REGEX},
+			},
+		},
+		{
+			`$"Hello ${name}!" $ $x`,
+			[]token.Token{
+				{Literal: `Hello ${name}!`, Type: token.INTERP},
+				{Literal: "$", Type: token.SYMBOL},
+				{Literal: "$x", Type: token.SYMBOL},
+			},
+		},
 		{
 			"`('(1 2 3) ,(+ 2 3))",
 			[]token.Token{
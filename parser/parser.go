@@ -4,7 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"regexp"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/twolodzko/gol/token"
 	"github.com/twolodzko/gol/types"
@@ -18,6 +22,10 @@ type (
 	String = types.String
 	Symbol = types.Symbol
 	List   = types.List
+
+	Complex = types.Complex
+	Char    = types.Char
+	Regex   = types.Regex
 )
 
 func Parse(r io.Reader) ([]Any, error) {
@@ -105,11 +113,21 @@ func (p *Parser) Parse() ([]Any, error) {
 		case token.BOOL:
 			obj, err = Bool(t.Literal == "true"), nil
 		case token.INT:
-			obj, err = ParseInt(t.Literal)
+			obj, err = ParseInteger(t.Literal)
 		case token.FLOAT:
 			obj, err = ParseFloat(t.Literal)
+		case token.RATIO:
+			obj, err = ParseRatio(t.Literal)
+		case token.CMPLX:
+			obj, err = ParseComplex(t.Literal)
 		case token.STRING:
 			obj = String(t.Literal)
+		case token.CHAR:
+			obj, err = ParseChar(t.Literal)
+		case token.REGEX:
+			obj, err = ParseRegex(t.Literal)
+		case token.INTERP:
+			obj, err = ParseInterpolated(t.Literal)
 		case token.SYMBOL:
 			obj = Symbol(t.Literal)
 		}
@@ -147,13 +165,165 @@ func (p *Parser) Parse() ([]Any, error) {
 }
 
 func ParseInt(s string) (Int, error) {
-	return strconv.Atoi(s)
+	i, err := strconv.ParseInt(s, intBase(s), 0)
+	return Int(i), err
+}
+
+// intBase returns 0 for the integers using the 0x, 0b, 0o prefixes or
+// the _ digits separator, so that the base is inferred from the literal,
+// otherwise the integers are decimal, even if they start with 0
+func intBase(s string) int {
+	s = strings.TrimLeft(s, "+-")
+	if len(s) > 1 && s[0] == '0' && strings.ContainsRune("xXbBoO", rune(s[1])) {
+		return 0
+	}
+	if strings.ContainsRune(s, '_') && s[0] != '0' {
+		return 0
+	}
+	return 10
 }
 
 func ParseFloat(s string) (Float, error) {
 	return strconv.ParseFloat(s, 64)
 }
 
+// ParseChar parses the character literal, that is either a single
+// character, its name, or a hexadecimal code point, e.g. x3bb or u03bb
+func ParseChar(s string) (Char, error) {
+	runes := []rune(s)
+	if len(runes) == 1 {
+		return Char(runes[0]), nil
+	}
+	if c, ok := types.CharNames[s]; ok {
+		return c, nil
+	}
+	if runes[0] == 'x' || runes[0] == 'u' {
+		code, err := strconv.ParseUint(string(runes[1:]), 16, 32)
+		if err == nil && utf8.ValidRune(rune(code)) {
+			return Char(code), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid character: #\\%s", s)
+}
+
+// ParseRegex compiles the regular expression, so invalid
+// expressions are reported as parsing errors
+func ParseRegex(s string) (Regex, error) {
+	re, err := regexp.Compile(s)
+	if err != nil {
+		return Regex{}, err
+	}
+	anchored, err := regexp.Compile(`^(?:` + s + `)$`)
+	return Regex{Regexp: re, Anchored: anchored}, err
+}
+
+// ParseInterpolated expands the interpolated string "Hello ${name}!"
+// to the (str "Hello " name "!") call
+func ParseInterpolated(s string) (Any, error) {
+	var (
+		parts   List
+		literal strings.Builder
+	)
+
+	for i := 0; i < len(s); i++ {
+		if !strings.HasPrefix(s[i:], "${") {
+			literal.WriteByte(s[i])
+			continue
+		}
+
+		end, err := closingBrace(s, i+2)
+		if err != nil {
+			return nil, err
+		}
+		// the strings within the expression are escaped in the literal
+		expr := strings.ReplaceAll(s[i+2:end], `\"`, `"`)
+		exprs, err := Parse(strings.NewReader(expr))
+		if err != nil {
+			return nil, err
+		}
+		if len(exprs) != 1 {
+			return nil, fmt.Errorf("expected single expression in ${%s}", s[i+2:end])
+		}
+
+		if literal.Len() > 0 {
+			parts = append(parts, String(literal.String()))
+			literal.Reset()
+		}
+		parts = append(parts, exprs[0])
+		i = end
+	}
+
+	if parts == nil {
+		return String(s), nil
+	}
+	if literal.Len() > 0 {
+		parts = append(parts, String(literal.String()))
+	}
+	return append(List{Symbol("str")}, parts...), nil
+}
+
+// closingBrace returns the index of the } matching the ${ opening,
+// ignoring the braces within the (escaped) strings
+func closingBrace(s string, start int) (int, error) {
+	var (
+		depth    int
+		inString bool
+	)
+	for i := start; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], `\"`):
+			inString = !inString
+			i++
+		case s[i] == '\\':
+			i++
+		case inString:
+		case s[i] == '{':
+			depth++
+		case s[i] == '}':
+			if depth == 0 {
+				return i, nil
+			}
+			depth--
+		}
+	}
+	return 0, errors.New("missing closing brace in ${")
+}
+
+func ParseComplex(s string) (Complex, error) {
+	c, err := strconv.ParseComplex(s, 128)
+	return Complex(c), err
+}
+
+// ParseInteger parses the integer, falling back to big integer
+// if it does not fit in Int
+func ParseInteger(s string) (Any, error) {
+	i, err := ParseInt(s)
+	if err == nil {
+		return i, nil
+	}
+	if numErr, ok := err.(*strconv.NumError); !ok || numErr.Err != strconv.ErrRange {
+		return nil, err
+	}
+	b, ok := new(big.Int).SetString(s, intBase(s))
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %s", s)
+	}
+	return b, nil
+}
+
+// ParseRatio parses the n/d fraction as an exact rational number,
+// or an integer if the denominator divides the numerator
+func ParseRatio(s string) (Any, error) {
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid rational number: %s", s)
+	}
+	if !r.IsInt() {
+		return r, nil
+	}
+	return ParseInteger(r.Num().String())
+}
+
 func (p *Parser) parseList() (List, error) {
 	l, err := p.Parse()
 	return List(l), err
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"io"
 	"strings"
 	"testing"
@@ -18,6 +19,8 @@ func TestParse(t *testing.T) {
 		{"bar ", []Any{Symbol("bar")}},
 		{"foo bar\n", []Any{Symbol("foo"), Symbol("bar")}},
 		{"42", []Any{Int(42)}},
+		{"4/2", []Any{Int(2)}},
+		{`#\a #\space #\u03bb`, []Any{Char('a'), Char(' '), Char('λ')}},
 		{`"Hello World!" `, []Any{String("Hello World!")}},
 		{"1e-7", []Any{Float(1e-7)}},
 		{" \n\t bar", []Any{Symbol("bar")}},
@@ -50,6 +53,76 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseNumbers(t *testing.T) {
+	var testCases = []struct {
+		input    string
+		expected string
+	}{
+		{"1/3", "1/3"},
+		{"-2/4", "-1/2"},
+		{"6/3", "2"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{"-123456789012345678901234567890/2", "-61728394506172839450617283945"},
+		{"0x1F", "31"},
+		{"-0b1010", "-10"},
+		{"0o755", "493"},
+		{"1_000_000", "1000000"},
+		{"007", "7"},
+		{"0x1_0000_0000_0000_0000", "18446744073709551616"},
+	}
+
+	for _, tt := range testCases {
+		result, err := Parse(strings.NewReader(tt.input))
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if len(result) != 1 || fmt.Sprint(result[0]) != tt.expected {
+			t.Errorf("expected: %v, got: %v", tt.expected, result)
+		}
+	}
+
+	for _, input := range []string{"1/0", "1__0", "0x_", `#\foo`, `#\`} {
+		result, err := Parse(strings.NewReader(input))
+		if err == nil {
+			t.Errorf("expected and error, got result: %v", result)
+		}
+	}
+}
+
+func TestParseInterpolated(t *testing.T) {
+	var testCases = []struct {
+		input    string
+		expected string
+	}{
+		{`$"Hello"`, `"Hello"`},
+		{`$""`, `""`},
+		{`$"Hello ${name}!"`, `(str "Hello " name "!")`},
+		{`$"${x}${y}"`, `(str x y)`},
+		{`$"${(+ 1 2)} = 3"`, `(str (+ 1 2) " = 3")`},
+		{`$"${(get {a} \"}\")}"`, `(str (get {a} "}"))`},
+		{`$"costs $5"`, `"costs $5"`},
+	}
+
+	for _, tt := range testCases {
+		result, err := Parse(strings.NewReader(tt.input))
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if len(result) != 1 || fmt.Sprint(result[0]) != tt.expected {
+			t.Errorf("expected: %v, got: %v", tt.expected, result)
+		}
+	}
+
+	for _, input := range []string{`$"${x"`, `$"${}"`, `$"${x y}"`, `$"${(x}"`} {
+		result, err := Parse(strings.NewReader(input))
+		if err == nil {
+			t.Errorf("expected and error, got result: %v", result)
+		}
+	}
+}
+
 func TestParse_InvalidInput(t *testing.T) {
 	var testCases = []string{
 		"(",
@@ -13,7 +13,17 @@ type CodeReader struct {
 }
 
 func NewCodeReader(r io.Reader) *CodeReader {
-	return &CodeReader{bufio.NewReader(r), rune(0)}
+	cr := &CodeReader{bufio.NewReader(r), rune(0)}
+	cr.skipShebang()
+	return cr
+}
+
+// skipShebang skips the #!/usr/bin/env gol line at the beginning of the script
+func (cr *CodeReader) skipShebang() {
+	if start, err := cr.Peek(2); err == nil && string(start) == "#!" {
+		// error will be returned when reading the next rune
+		_ = cr.skipLine()
+	}
 }
 
 func (cr *CodeReader) NextRune() error {
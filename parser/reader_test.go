@@ -52,3 +52,26 @@ func TestCodeReader(t *testing.T) {
 		}
 	}
 }
+
+func TestCodeReader_Shebang(t *testing.T) {
+	var testCases = []struct {
+		input    string
+		expected rune
+	}{
+		{"#!/usr/bin/env gol\n(foo)", '('},
+		{"#!/usr/bin/env gol", '\x00'},
+		{"#\\a", '#'},
+	}
+
+	for _, tt := range testCases {
+		cr := NewCodeReader(strings.NewReader(tt.input))
+		err := cr.NextRune()
+
+		if err != nil && err != io.EOF {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if cr.Head != tt.expected {
+			t.Errorf("for %q expected %q, got: %q", tt.input, tt.expected, cr.Head)
+		}
+	}
+}
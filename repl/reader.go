@@ -46,7 +46,15 @@ func (repl *Repl) read() (string, error) {
 }
 
 func (reader *blockReader) shouldStop(line string) bool {
-	for _, r := range line {
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		// character literal, e.g. #\(
+		if !reader.isQuoted && r == '#' && i+2 < len(runes) && runes[i+1] == '\\' {
+			i += 2
+			continue
+		}
 
 		switch r {
 		case '"':
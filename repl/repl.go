@@ -3,6 +3,7 @@ package repl
 import (
 	"bufio"
 	"io"
+	"strings"
 
 	"github.com/twolodzko/gol/evaluator"
 	"github.com/twolodzko/gol/types"
@@ -16,12 +17,22 @@ type Repl struct {
 }
 
 func NewRepl(in io.Reader) *Repl {
-	eval := evaluator.NewEvaluator()
-	return &Repl{bufio.NewReader(in), eval}
+	return NewReplWithEvaluator(in, evaluator.NewEvaluator())
 }
 
+// NewReplWithEvaluator creates REPL that shares the state with the evaluator
+func NewReplWithEvaluator(in io.Reader, e *evaluator.Evaluator) *Repl {
+	return &Repl{bufio.NewReader(in), e}
+}
+
+// Repl reads and evaluates the next block of code, it returns io.EOF
+// when there is nothing more to read
 func (repl *Repl) Repl() ([]Any, error) {
 	cmd, err := repl.read()
+	if err == io.EOF && strings.TrimSpace(cmd) != "" {
+		// evaluate the last block, the EOF is returned with the next call
+		err = nil
+	}
 	if err != nil {
 		return nil, err
 	}
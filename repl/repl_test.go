@@ -1,6 +1,7 @@
 package repl
 
 import (
+	"io"
 	"strings"
 	"testing"
 )
@@ -24,3 +25,45 @@ func TestRead_InvalidInput(t *testing.T) {
 		}
 	}
 }
+
+func TestRead_CharLiterals(t *testing.T) {
+	var testCases = []struct {
+		input    string
+		expected string
+	}{
+		{"(list #\\( #\\))\n", "(list #\\( #\\))\n"},
+		{"(list #\\a\n #\\))\n", "(list #\\a\n #\\))\n"},
+	}
+
+	for _, tt := range testCases {
+		repl := NewRepl(strings.NewReader(tt.input))
+		result, err := repl.read()
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+		if result != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, result)
+		}
+	}
+}
+
+func TestRepl_EOF(t *testing.T) {
+	repl := NewRepl(strings.NewReader("(def x 2)\n(+ x 3)"))
+
+	var results []Any
+	for {
+		objs, err := repl.Repl()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		results = append(results, objs...)
+	}
+
+	if len(results) != 2 || results[1] != 5 {
+		t.Errorf("unexpected results: %v", results)
+	}
+}
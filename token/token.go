@@ -6,7 +6,12 @@ const (
 	BOOL   = "bool"
 	INT    = "int"
 	FLOAT  = "float"
+	RATIO  = "ratio"
+	CMPLX  = "complex"
 	STRING = "str"
+	CHAR   = "char"
+	REGEX  = "regex"
+	INTERP = "interp"
 	SYMBOL = "sym"
 	LPAREN = "("
 	RPAREN = ")"
@@ -29,7 +34,7 @@ func (t Token) String() string {
 	switch t.Type {
 	case LPAREN, RPAREN, NIL, QUOTE, TICK, COMMA:
 		return t.Type
-	case BOOL, INT, FLOAT, STRING, SYMBOL:
+	case BOOL, INT, FLOAT, RATIO, CMPLX, STRING, CHAR, REGEX, INTERP, SYMBOL:
 		return fmt.Sprintf("%q:%s", t.Literal, t.Type)
 	default:
 		return "<invalid token>"
@@ -2,8 +2,13 @@ package types
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type (
@@ -14,8 +19,116 @@ type (
 	Int    = int
 	Float  = float64
 	Any    = interface{}
+
+	BigInt   = *big.Int
+	Rational = *big.Rat
+	Complex  complex128
+	Char     rune
+	Map      map[Any]Any
 )
 
+// Regex is a compiled regular expression, Anchored matches
+// the whole string with the same expression
+type Regex struct {
+	*regexp.Regexp
+	Anchored *regexp.Regexp
+}
+
+func (r Regex) String() string {
+	return `#"` + r.Regexp.String() + `"`
+}
+
+// String prints the map with keys sorted by their representation,
+// so that the output is deterministic
+func (m Map) String() string {
+	var entries []string
+	for k, v := range m {
+		entries = append(entries, fmt.Sprintf("%v %v", k, v))
+	}
+	sort.Strings(entries)
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// Time is the point in time, printed using the RFC 3339 format
+type Time struct {
+	time.Time
+}
+
+func (t Time) String() string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// Duration is the time elapsed between two points in time, printed like 1h2m3.5s
+type Duration time.Duration
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// Matrix is the dense matrix of floats, stored in the row-major order
+type Matrix struct {
+	Rows, Cols int
+	Data       []float64
+}
+
+func (m Matrix) At(i, j int) float64 {
+	return m.Data[i*m.Cols+j]
+}
+
+// String prints the matrix as the nested lists of rows, one row per line,
+// with the columns right-aligned
+func (m Matrix) String() string {
+	cells := make([]string, len(m.Data))
+	widths := make([]int, m.Cols)
+	for i, x := range m.Data {
+		cells[i] = strconv.FormatFloat(x, 'g', -1, 64)
+		if j := i % m.Cols; len(cells[i]) > widths[j] {
+			widths[j] = len(cells[i])
+		}
+	}
+
+	var rows []string
+	for i := 0; i < m.Rows; i++ {
+		var row []string
+		for j := 0; j < m.Cols; j++ {
+			row = append(row, fmt.Sprintf("%*s", widths[j], cells[i*m.Cols+j]))
+		}
+		rows = append(rows, "("+strings.Join(row, " ")+")")
+	}
+	return "#matrix(" + strings.Join(rows, "\n        ") + ")"
+}
+
+// RecordType is the type defined using defrecord
+type RecordType struct {
+	Name   Symbol
+	Fields []Symbol
+}
+
+// Record is the immutable instance of the record type,
+// with the values of the fields in the order of their definition
+type Record struct {
+	Type   *RecordType
+	Values []Any
+}
+
+func (r Record) String() string {
+	var fields []string
+	for i, name := range r.Type.Fields {
+		fields = append(fields, fmt.Sprintf(":%s %v", name, r.Values[i]))
+	}
+	return "#" + string(r.Type.Name) + "{" + strings.Join(fields, " ") + "}"
+}
+
+// CharNames are the names of the special characters,
+// that can be used in the character literals, e.g. #\newline
+var CharNames = map[string]Char{
+	"nul":     0,
+	"tab":     '\t',
+	"newline": '\n',
+	"return":  '\r',
+	"space":   ' ',
+}
+
 func (s String) String() string {
 	return fmt.Sprintf("\"%s\"", string(s))
 }
@@ -30,9 +143,38 @@ func (s String) Quote() String {
 	return String(runes[1 : len(runes)-1])
 }
 
+// Unquote interprets the escape sequences, the characters
+// that are not escaped, like new lines, are kept as-is
 func (s String) Unquote() (String, error) {
-	str, err := strconv.Unquote(`"` + string(s) + `"`)
-	return String(str), err
+	var buf []byte
+	str := string(s)
+	for len(str) > 0 {
+		r, multibyte, tail, err := strconv.UnquoteChar(str, '"')
+		if err != nil {
+			return "", err
+		}
+		if r < utf8.RuneSelf || !multibyte {
+			buf = append(buf, byte(r))
+		} else {
+			buf = append(buf, string(r)...)
+		}
+		str = tail
+	}
+	return String(buf), nil
+}
+
+func (c Complex) String() string {
+	str := strconv.FormatComplex(complex128(c), 'g', -1, 128)
+	return str[1 : len(str)-1]
+}
+
+func (c Char) String() string {
+	for name, r := range CharNames {
+		if c == r {
+			return `#\` + name
+		}
+	}
+	return `#\` + string(c)
 }
 
 func (l List) String() string {
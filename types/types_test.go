@@ -58,4 +58,46 @@ func TestString(t *testing.T) {
 	if unquoted != str {
 		t.Errorf("expected %s, got: %s", str, unquoted)
 	}
+
+	// the characters that are not escaped are kept
+	unquoted, err = String("a\tb\n\\x41\\u0105\\n").Unquote()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if unquoted != "a\tb\nAą\n" {
+		t.Errorf("expected %q, got: %q", "a\tb\nAą\n", unquoted)
+	}
+	if _, err := String(`a\qb`).Unquote(); err == nil {
+		t.Errorf("expected an error for invalid escape sequence")
+	}
+}
+
+func TestMatrixString(t *testing.T) {
+	var testCases = []struct {
+		input    Matrix
+		expected string
+	}{
+		{Matrix{}, "#matrix()"},
+		{Matrix{Rows: 1, Cols: 2, Data: []float64{1, 2}}, "#matrix((1 2))"},
+		{
+			Matrix{Rows: 2, Cols: 2, Data: []float64{1, -2.5, 30, 4}},
+			"#matrix(( 1 -2.5)\n        (30    4))",
+		},
+	}
+
+	for _, tt := range testCases {
+		if tt.input.String() != tt.expected {
+			t.Errorf("expected %q, got: %q", tt.expected, tt.input.String())
+		}
+	}
+}
+
+func TestRecordString(t *testing.T) {
+	point := &RecordType{Name: "Point", Fields: []Symbol{"x", "y"}}
+	r := Record{Type: point, Values: []Any{1, String("a")}}
+	expected := `#Point{:x 1 :y "a"}`
+
+	if r.String() != expected {
+		t.Errorf("expected %s, got: %s", expected, r.String())
+	}
 }